@@ -0,0 +1,154 @@
+package navitia
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/govitia/navitia/types"
+)
+
+// coverageTTL is the time after which the cached coverage is considered stale and is fetched again
+const coverageTTL = 12 * time.Hour
+
+// coverageRetry is the time during which a failure to fetch the coverage is returned again, rather than fetching it again
+const coverageRetry = time.Minute
+
+// coverage caches the regions covered by the API along with their shapes, allowing us to scope requests automatically.
+type coverage struct {
+	mu     sync.RWMutex
+	index  *types.RegionIndex
+	loaded time.Time
+
+	// The last failure to fetch the coverage, if it failed
+	err    error
+	failed time.Time
+
+	// loading is held while fetching, so that concurrent refreshes are collapsed into one
+	loading chan struct{}
+}
+
+// newCoverage returns an empty coverage
+func newCoverage() *coverage {
+	return &coverage{loading: make(chan struct{}, 1)}
+}
+
+// cached returns the cached regions index if it is fresh, or the last failure if it is recent.
+// It reports whether either was found.
+func (c *coverage) cached() (*types.RegionIndex, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	switch {
+	case !c.loaded.IsZero() && time.Since(c.loaded) < coverageTTL:
+		return c.index, true, nil
+	case c.err != nil && time.Since(c.failed) < coverageRetry:
+		return nil, true, c.err
+	}
+	return nil, false, nil
+}
+
+// get returns the cached regions index, fetching the regions first if they're missing or stale.
+func (c *coverage) get(ctx context.Context, s *Session) (*types.RegionIndex, error) {
+	if index, ok, err := c.cached(); ok {
+		return index, err
+	}
+	return c.refresh(ctx, s, false)
+}
+
+// refresh fetches the regions along with their shapes, and caches them, or the failure to fetch them.
+//
+// Only one refresh runs at a time: unless forced, a refresh waiting for another one uses its outcome.
+func (c *coverage) refresh(ctx context.Context, s *Session, force bool) (*types.RegionIndex, error) {
+	select {
+	case c.loading <- struct{}{}:
+		defer func() { <-c.loading }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if !force {
+		if index, ok, err := c.cached(); ok {
+			return index, err
+		}
+	}
+
+	res, err := s.Regions(ctx, RegionRequest{Geo: true})
+	if err != nil {
+		err = errors.Wrap(err, "error while fetching coverage")
+
+		// A cancelled request says nothing about the API, so it isn't cached
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.err = err
+		c.failed = time.Now()
+		c.mu.Unlock()
+
+		return nil, err
+	}
+	index := types.NewRegionIndex(res.Regions)
+
+	c.mu.Lock()
+	c.index = index
+	c.loaded = time.Now()
+	c.err = nil
+	c.mu.Unlock()
+
+	return index, nil
+}
+
+// RefreshCoverage fetches the regions covered by the API, replacing those cached for automatic scoping.
+//
+// The coverage is otherwise fetched on first use, and refreshed every 12 hours.
+// When fetching it fails, the failure is returned for a minute before trying again.
+func (s *Session) RefreshCoverage(ctx context.Context) error {
+	_, err := s.coverage.refresh(ctx, s, true)
+	return err
+}
+
+// Locate returns the ID of the first region whose shape contains all the given coordinates.
+// If none match, Locate returns an empty ID.
+//
+// The regions are fetched and cached on first use.
+func (s *Session) Locate(ctx context.Context, coords ...types.Coordinates) (types.ID, error) {
+	if len(coords) == 0 {
+		return "", nil
+	}
+
+	index, err := s.coverage.get(ctx, s)
+	if err != nil {
+		return "", err
+	}
+
+	for _, r := range index.Covering(coords[0]) {
+		contained := true
+		for _, c := range coords[1:] {
+			if !r.Contains(c) {
+				contained = false
+				break
+			}
+		}
+		if contained {
+			return r.ID, nil
+		}
+	}
+
+	return "", nil
+}
+
+// locateIDs returns the ID of the region containing the given places, if they're coordinates.
+// Places that aren't coordinates (such as a stop area ID) are ignored.
+func (s *Session) locateIDs(ctx context.Context, ids ...types.ID) (types.ID, error) {
+	coords := make([]types.Coordinates, 0, len(ids))
+	for _, id := range ids {
+		if c, err := types.ParseCoordinates(id); err == nil {
+			coords = append(coords, c)
+		}
+	}
+	return s.Locate(ctx, coords...)
+}
@@ -0,0 +1,126 @@
+package navitia
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// TestSession_AutoScope checks that journeys are scoped to the region containing their origin & destination
+func TestSession_AutoScope(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/coverage":
+			_, _ = w.Write([]byte(`{"regions": [{"id": "fr-idf", "shape": "MULTIPOLYGON(((2 48,3 48,3 49,2 49,2 48)))"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"journeys": []}`))
+		}
+	}))
+	defer srv.Close()
+
+	s, err := NewCustom("key", srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("error in NewCustom: %v", err)
+	}
+	s.AutoScope = true
+
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		from, to types.Coordinates
+		path     string
+	}{
+		{"within", types.Coordinates{Longitude: 2.38, Latitude: 48.84}, types.Coordinates{Longitude: 2.35, Latitude: 48.86}, "/coverage/fr-idf/journeys"},
+		{"outside", types.Coordinates{Longitude: 2.38, Latitude: 48.84}, types.Coordinates{Longitude: 4.83, Latitude: 45.76}, "/journeys"},
+	}
+	for _, tt := range tests {
+		paths = nil
+		if _, err := s.Journeys(ctx, JourneyRequest{From: tt.from.ID(), To: tt.to.ID()}); err != nil {
+			t.Fatalf("%s: error in Journeys: %v", tt.name, err)
+		}
+		if got := paths[len(paths)-1]; got != tt.path {
+			t.Errorf("%s: expected request to %s, got %s", tt.name, tt.path, got)
+		}
+	}
+}
+
+// TestSession_AutoScope_CoverageError checks that journeys fall back to the global endpoint when the coverage can't be fetched
+func TestSession_AutoScope_CoverageError(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/coverage":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error": {"id": "service_unavailable", "message": "down"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"journeys": []}`))
+		}
+	}))
+	defer srv.Close()
+
+	s, err := NewCustom("key", srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("error in NewCustom: %v", err)
+	}
+	s.AutoScope = true
+
+	from := types.Coordinates{Longitude: 2.38, Latitude: 48.84}
+	to := types.Coordinates{Longitude: 2.35, Latitude: 48.86}
+	if _, err := s.Journeys(context.Background(), JourneyRequest{From: from.ID(), To: to.ID()}); err != nil {
+		t.Fatalf("error in Journeys: %v", err)
+	}
+	if got := paths[len(paths)-1]; got != "/journeys" {
+		t.Errorf("expected request to /journeys, got %s", got)
+	}
+
+	// The failure is cached, so the coverage isn't fetched again right away
+	paths = nil
+	if _, err := s.Journeys(context.Background(), JourneyRequest{From: from.ID(), To: to.ID()}); err != nil {
+		t.Fatalf("error in second call to Journeys: %v", err)
+	}
+	if len(paths) != 1 || paths[0] != "/journeys" {
+		t.Errorf("expected a single request to /journeys, got %v", paths)
+	}
+}
+
+// TestSession_Locate_Concurrent checks that concurrent lookups fetch the coverage only once
+func TestSession_Locate_Concurrent(t *testing.T) {
+	var fetches int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"regions": [{"id": "fr-idf", "shape": "MULTIPOLYGON(((2 48,3 48,3 49,2 49,2 48)))"}]}`))
+	}))
+	defer srv.Close()
+
+	s, err := NewCustom("key", srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("error in NewCustom: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			region, err := s.Locate(context.Background(), types.Coordinates{Longitude: 2.38, Latitude: 48.84})
+			if err != nil || region != "fr-idf" {
+				t.Errorf("unexpected result of Locate: %q, %v", region, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Errorf("expected the coverage to be fetched once, got %d", n)
+	}
+}
@@ -0,0 +1,99 @@
+package navitia
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/govitia/navitia/types"
+	"github.com/govitia/navitia/utils"
+)
+
+const calendarsEndpoint string = "calendars"
+
+// CalendarsResults holds the calendars of a region, to be used with the Calendar parameter of departures & arrivals requests.
+type CalendarsResults struct {
+	Calendars []types.Calendar `json:"calendars"`
+
+	Disruptions []types.Disruption `json:"disruptions"`
+
+	Paging Paging `json:"links"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of calendars in the results
+func (cr *CalendarsResults) Count() int {
+	return len(cr.Calendars)
+}
+
+// CalendarsRequest contains the optional parameters for a calendars request.
+type CalendarsRequest struct {
+	// Only keep the calendars active between these dates
+	StartDate time.Time
+	EndDate   time.Time
+
+	// Forbidden public transport objects
+	Forbidden []types.ID
+
+	// Filter is a PT-Ref filter, such as "line.id=line:RAT:M14"
+	Filter string
+
+	// The maximum amount of results per page (default 25), and the page to start with
+	Count     uint
+	StartPage uint
+
+	// Depth of the objects in the reply, from 0 to 3. If nil, the server's default of 1 is used
+	Depth *uint
+}
+
+func (req CalendarsRequest) toURL() (url.Values, error) {
+	rb := utils.NewRequestBuilder()
+
+	rb.AddDate("start_date", req.StartDate)
+	rb.AddDate("end_date", req.EndDate)
+	rb.AddIDSlice("forbidden_uris[]", req.Forbidden)
+	rb.AddString("filter", req.Filter)
+	if req.Count != 0 {
+		rb.AddUInt("count", req.Count)
+	}
+	if req.StartPage != 0 {
+		rb.AddUInt("start_page", req.StartPage)
+	}
+	if req.Depth != nil {
+		rb.AddUInt("depth", *req.Depth)
+	}
+
+	return rb.Values(), nil
+}
+
+// calendars is the internal function used by Calendars functions
+func (s *Session) calendars(ctx context.Context, url string, req CalendarsRequest) (*CalendarsResults, error) {
+	results := &CalendarsResults{session: s}
+	err := s.request(ctx, url, req, results)
+	return results, err
+}
+
+// Calendars lists the calendars of the region, such as "weekdays" or "saturdays".
+func (scope *Scope) Calendars(ctx context.Context, req CalendarsRequest) (*CalendarsResults, error) {
+	// Create the URL
+	reqURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + calendarsEndpoint
+
+	return scope.session.calendars(ctx, reqURL, req)
+}
+
+// ObjectCalendars lists the calendars of the given object of the region, such as a line or a stop area.
+// It accepts the same objects as Departures.
+func (scope *Scope) ObjectCalendars(ctx context.Context, req CalendarsRequest, object types.ID) (*CalendarsResults, error) {
+	path, err := objectPath(object)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the URL
+	reqURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + path + "/" + calendarsEndpoint
+
+	return scope.session.calendars(ctx, reqURL, req)
+}
@@ -0,0 +1,124 @@
+package navitia
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// Test_CalendarsResults_Unmarshal tests unmarshalling for CalendarsResults.
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+//
+//	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_CalendarsResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["calendars"], reflect.TypeOf(CalendarsResults{}))
+}
+
+// Test_CalendarsRequest_toURL checks that every parameter of a CalendarsRequest is encoded, the unset depth being left out
+func Test_CalendarsRequest_toURL(t *testing.T) {
+	t.Parallel()
+
+	req := CalendarsRequest{
+		StartDate: time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2017, time.June, 30, 0, 0, 0, 0, time.UTC),
+		Forbidden: []types.ID{"line:OIF:1"},
+		Filter:    "line.id=line:RAT:M14",
+		Count:     10,
+		StartPage: 1,
+	}
+	values, err := req.toURL()
+	if err != nil {
+		t.Fatalf("error in CalendarsRequest.toURL: %v", err)
+	}
+
+	want := map[string]string{
+		"start_date":       "20170401",
+		"end_date":         "20170630",
+		"forbidden_uris[]": "line:OIF:1",
+		"filter":           "line.id=line:RAT:M14",
+		"count":            "10",
+		"start_page":       "1",
+	}
+	for key, value := range want {
+		if got := values.Get(key); got != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+	if _, ok := values["depth"]; ok {
+		t.Errorf("expected no depth, got %q", values.Get("depth"))
+	}
+
+	// A depth of 0 is a valid one
+	depth := uint(0)
+	req.Depth = &depth
+	values, err = req.toURL()
+	if err != nil {
+		t.Fatalf("error in CalendarsRequest.toURL: %v", err)
+	}
+	if got := values.Get("depth"); got != "0" {
+		t.Errorf("expected depth=%q, got %q", "0", got)
+	}
+}
+
+// TestScope_Calendars checks that calendars are requested on the region or on the given object, and evaluated on their dates
+func TestScope_Calendars(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write(testData["calendars"].correct["doc.json"])
+	}))
+	defer srv.Close()
+
+	s, err := NewCustom("key", srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("error in NewCustom: %v", err)
+	}
+	scope := s.Scope("fr-idf")
+	ctx := context.Background()
+
+	res, err := scope.Calendars(ctx, CalendarsRequest{})
+	if err != nil {
+		t.Fatalf("error in Calendars: %v", err)
+	}
+	if want := "/coverage/fr-idf/calendars"; path != want {
+		t.Errorf("Calendars: expected request to %s, got %s", want, path)
+	}
+	if res.Count() != 2 || res.Calendars[0].ID != "Semaine" || res.Calendars[1].Name != "Dimanche" {
+		t.Fatalf("unexpected calendars: %+v", res.Calendars)
+	}
+
+	// Labour day, a monday, is removed from the validity pattern of the weekdays, and added to the sundays
+	weekdays, sundays := res.Calendars[0], res.Calendars[1]
+	dates := []struct {
+		date              time.Time
+		weekdays, sundays bool
+	}{
+		{time.Date(2017, time.April, 28, 0, 0, 0, 0, time.UTC), true, false},
+		{time.Date(2017, time.April, 30, 0, 0, 0, 0, time.UTC), false, true},
+		{time.Date(2017, time.May, 1, 0, 0, 0, 0, time.UTC), false, true},
+		{time.Date(2017, time.May, 2, 0, 0, 0, 0, time.UTC), true, false},
+		{time.Date(2017, time.July, 10, 0, 0, 0, 0, time.UTC), false, false},
+	}
+	for _, tt := range dates {
+		if got := weekdays.RunsOn(tt.date); got != tt.weekdays {
+			t.Errorf("weekdays on %s: got %t, want %t", tt.date.Format("2006-01-02"), got, tt.weekdays)
+		}
+		if got := sundays.RunsOn(tt.date); got != tt.sundays {
+			t.Errorf("sundays on %s: got %t, want %t", tt.date.Format("2006-01-02"), got, tt.sundays)
+		}
+	}
+
+	if _, err := scope.ObjectCalendars(ctx, CalendarsRequest{}, "line:RAT:M14"); err != nil {
+		t.Fatalf("error in ObjectCalendars: %v", err)
+	}
+	if want := "/coverage/fr-idf/lines/line:RAT:M14/calendars"; path != want {
+		t.Errorf("ObjectCalendars: expected request to %s, got %s", want, path)
+	}
+}
@@ -0,0 +1,213 @@
+package navitia
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/govitia/navitia/types"
+)
+
+// A JourneyComparison holds the journeys computed on the base schedule, each compared with its realtime counterpart.
+type JourneyComparison struct {
+	// The compared journeys, in the order of the base-schedule results
+	Journeys []ComparedJourney
+
+	// Raw results of both requests
+	Realtime     *JourneyResults
+	BaseSchedule *JourneyResults
+}
+
+// A ComparedJourney is a base-schedule journey along with the realtime journey taking the most of its vehicle journeys.
+type ComparedJourney struct {
+	Base types.Journey
+
+	// The matching realtime journey, nil if none takes any of the base journey's vehicle journeys
+	Realtime *types.Journey
+
+	// Status of the realtime journey, the most disturbing effect on the objects it uses
+	Status types.Effect
+
+	// Delay of the arrival at destination, 0 if there's no matching realtime journey
+	Delay time.Duration
+
+	// Comparison of each public transport section of the base journey
+	Sections []ComparedSection
+}
+
+// A ComparedSection compares a public transport section of a base-schedule journey with the realtime one taking the same vehicle journey.
+type ComparedSection struct {
+	VehicleJourney types.ID
+
+	Base types.Section
+
+	// The realtime section, nil if the vehicle journey can't be taken anymore
+	Realtime *types.Section
+
+	// Delays of the departure & arrival
+	DepartureDelay time.Duration
+	ArrivalDelay   time.Duration
+
+	// Cancelled is true when no realtime journey takes the vehicle journey from the same stop anymore
+	Cancelled bool
+
+	// Rerouted is true when the vehicle journey is still taken, but boarded or left at another stop
+	Rerouted bool
+}
+
+// CompareJourneys computes the journeys of the request both on the base schedule and with realtime data, concurrently,
+// and matches them by vehicle journey and stop to report the delays, cancellations and reroutings.
+//
+// The request's Freshness is overridden.
+func (s *Session) CompareJourneys(ctx context.Context, req JourneyRequest) (*JourneyComparison, error) {
+	return compareJourneys(ctx, req, s.Journeys)
+}
+
+// CompareJourneys computes the journeys of the request in the scope's region, see Session.CompareJourneys.
+func (scope *Scope) CompareJourneys(ctx context.Context, req JourneyRequest) (*JourneyComparison, error) {
+	return compareJourneys(ctx, req, scope.Journeys)
+}
+
+// compareJourneys issues the realtime & base-schedule requests concurrently through the given journeys function, and compares their results.
+// The first request to fail cancels the other one.
+func compareJourneys(ctx context.Context, req JourneyRequest, journeys func(context.Context, JourneyRequest) (*JourneyResults, error)) (*JourneyComparison, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg             sync.WaitGroup
+		once           sync.Once
+		realtime, base *JourneyResults
+		firstErr       error
+	)
+
+	// fail records the first error and cancels the other request
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		rtReq := req
+		rtReq.Freshness = types.DataFreshnessRealTime
+		res, err := journeys(ctx, rtReq)
+		if err != nil {
+			fail(errors.Wrap(err, "error while requesting realtime journeys"))
+			return
+		}
+		realtime = res
+	}()
+	go func() {
+		defer wg.Done()
+		bsReq := req
+		bsReq.Freshness = types.DataFreshnessBaseSchedule
+		res, err := journeys(ctx, bsReq)
+		if err != nil {
+			fail(errors.Wrap(err, "error while requesting base-schedule journeys"))
+			return
+		}
+		base = res
+	}()
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	comparison := &JourneyComparison{
+		Journeys:     make([]ComparedJourney, len(base.Journeys)),
+		Realtime:     realtime,
+		BaseSchedule: base,
+	}
+	for i, j := range base.Journeys {
+		comparison.Journeys[i] = compareJourney(j, realtime.Journeys)
+	}
+	return comparison, nil
+}
+
+// compareJourney compares a base-schedule journey with the given realtime journeys
+func compareJourney(base types.Journey, realtime []types.Journey) ComparedJourney {
+	cj := ComparedJourney{Base: base}
+
+	// Find the realtime journey sharing the most vehicle journeys
+	baseVJs := journeyVehicleJourneys(base)
+	best := 0
+	for i := range realtime {
+		shared := 0
+		for vj := range journeyVehicleJourneys(realtime[i]) {
+			if baseVJs[vj] {
+				shared++
+			}
+		}
+		if shared > best {
+			best = shared
+			cj.Realtime = &realtime[i]
+		}
+	}
+	if cj.Realtime != nil {
+		cj.Status = cj.Realtime.Status
+		cj.Delay = cj.Realtime.Arrival.Sub(base.Arrival)
+	}
+
+	// Now compare each public transport section
+	for _, bs := range base.Sections {
+		vj := bs.VehicleJourneyID()
+		if vj == "" {
+			continue
+		}
+		cs := ComparedSection{VehicleJourney: vj, Base: bs}
+
+		// Look for it in the matched journey first, then in any realtime journey boarding it at the same stop
+		rs := findSection(cj.Realtime, vj, "")
+		if rs == nil {
+			for i := range realtime {
+				if rs = findSection(&realtime[i], vj, bs.From.ID); rs != nil {
+					break
+				}
+			}
+		}
+
+		if rs == nil {
+			cs.Cancelled = true
+		} else {
+			cs.Realtime = rs
+			cs.DepartureDelay = rs.Departure.Sub(bs.Departure)
+			cs.ArrivalDelay = rs.Arrival.Sub(bs.Arrival)
+			cs.Rerouted = rs.From.ID != bs.From.ID || rs.To.ID != bs.To.ID
+		}
+		cj.Sections = append(cj.Sections, cs)
+	}
+
+	return cj
+}
+
+// journeyVehicleJourneys returns the set of vehicle journeys taken in a journey
+func journeyVehicleJourneys(j types.Journey) map[types.ID]bool {
+	vjs := make(map[types.ID]bool, len(j.Sections))
+	for _, s := range j.Sections {
+		if vj := s.VehicleJourneyID(); vj != "" {
+			vjs[vj] = true
+		}
+	}
+	return vjs
+}
+
+// findSection returns the section of the journey taking the given vehicle journey, boarded at the given stop if not empty
+func findSection(j *types.Journey, vj types.ID, from types.ID) *types.Section {
+	if j == nil {
+		return nil
+	}
+	for i := range j.Sections {
+		s := &j.Sections[i]
+		if s.VehicleJourneyID() == vj && (from == "" || s.From.ID == from) {
+			return s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package navitia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// compareTestSection returns a public transport section taking the given vehicle journey between two stops
+func compareTestSection(vj, from, to, departure, arrival string) string {
+	return fmt.Sprintf(`{
+		"type": "public_transport",
+		"links": [{"id": %q, "type": "vehicle_journey"}],
+		"from": {"id": %q, "name": %q},
+		"to": {"id": %q, "name": %q},
+		"departure_date_time": %q,
+		"arrival_date_time": %q
+	}`, vj, from, from, to, to, departure, arrival)
+}
+
+// TestSession_CompareJourneys checks the matching of realtime & base-schedule journeys
+func TestSession_CompareJourneys(t *testing.T) {
+	base := fmt.Sprintf(`{"journeys": [{"arrival_date_time": "20170427T110000", "sections": [%s, %s, %s]}]}`,
+		compareTestSection("vj:A", "sp:1", "sp:2", "20170427T100000", "20170427T102000"),
+		compareTestSection("vj:B", "sp:2", "sp:3", "20170427T103000", "20170427T104000"),
+		compareTestSection("vj:C", "sp:3", "sp:4", "20170427T104500", "20170427T110000"),
+	)
+	realtime := fmt.Sprintf(`{"journeys": [{"arrival_date_time": "20170427T111000", "status": "SIGNIFICANT_DELAY", "sections": [%s, %s, %s]}]}`,
+		compareTestSection("vj:A", "sp:1", "sp:2", "20170427T100500", "20170427T102500"),
+		compareTestSection("vj:B", "sp:2", "sp:5", "20170427T103000", "20170427T104500"),
+		compareTestSection("vj:D", "sp:5", "sp:4", "20170427T105000", "20170427T111000"),
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("data_freshness") {
+		case string(types.DataFreshnessRealTime):
+			_, _ = w.Write([]byte(realtime))
+		case string(types.DataFreshnessBaseSchedule):
+			_, _ = w.Write([]byte(base))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	s, err := NewCustom("key", srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("error in NewCustom: %v", err)
+	}
+
+	res, err := s.CompareJourneys(context.Background(), JourneyRequest{From: "sp:1", To: "sp:4"})
+	if err != nil {
+		t.Fatalf("error in CompareJourneys: %v", err)
+	}
+	if len(res.Journeys) != 1 {
+		t.Fatalf("expected 1 compared journey, got %d", len(res.Journeys))
+	}
+
+	cj := res.Journeys[0]
+	if cj.Realtime == nil {
+		t.Fatal("expected a matching realtime journey")
+	}
+	if cj.Status != "SIGNIFICANT_DELAY" || cj.Delay != 10*time.Minute {
+		t.Errorf("journey: got status %q & delay %v", cj.Status, cj.Delay)
+	}
+
+	var got []string
+	for _, cs := range cj.Sections {
+		got = append(got, fmt.Sprintf("%s:%v/%v/%t/%t", cs.VehicleJourney, cs.DepartureDelay, cs.ArrivalDelay, cs.Cancelled, cs.Rerouted))
+	}
+	want := []string{
+		"vj:A:5m0s/5m0s/false/false",
+		"vj:B:0s/5m0s/false/true",
+		"vj:C:0s/0s/true/false",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("sections:\n\tgot  %v\n\twant %v", got, want)
+	}
+}
+
+// Test_compareJourneys_Cancel checks that the failure of one request cancels the other, and that its error is returned
+func Test_compareJourneys_Cancel(t *testing.T) {
+	journeys := func(ctx context.Context, req JourneyRequest) (*JourneyResults, error) {
+		if req.Freshness == types.DataFreshnessRealTime {
+			return nil, fmt.Errorf("realtime is down")
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return &JourneyResults{}, nil
+		}
+	}
+
+	start := time.Now()
+	_, err := compareJourneys(context.Background(), JourneyRequest{}, journeys)
+	if err == nil || !strings.Contains(err.Error(), "realtime is down") {
+		t.Fatalf("expected the realtime error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the base-schedule request wasn't cancelled, took %v", elapsed)
+	}
+}
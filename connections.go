@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"golang.org/x/text/language"
 
 	"github.com/govitia/navitia/types"
 	"github.com/govitia/navitia/utils"
@@ -13,17 +14,50 @@ import (
 
 // A Connection is either a Departure or an Arrival
 type Connection struct {
-	Display   types.Display
-	StopPoint types.StopPoint
-	Route     types.Route
-	// StopDateTime
+	Display      types.Display      `json:"display_informations"`
+	StopPoint    types.StopPoint    `json:"stop_point"`
+	Route        types.Route        `json:"route"`
+	StopDateTime types.StopDateTime `json:"stop_date_time"`
+
+	// Links to related objects, such as the vehicle journey
+	Links []types.Link `json:"links"`
+}
+
+// Delay returns how late the vehicle is compared to its base schedule, negative if it is early.
+// It is 0 when no base-scheduled time is known.
+func (c Connection) Delay() time.Duration {
+	return c.StopDateTime.Delay()
+}
+
+// IsRealtime reports whether the times of the connection come from realtime data.
+func (c Connection) IsRealtime() bool {
+	return c.StopDateTime.IsRealtime()
+}
+
+// VehicleJourneyID returns the ID of the vehicle journey serving the connection, or an empty ID if unknown.
+func (c Connection) VehicleJourneyID() types.ID {
+	for _, l := range c.Links {
+		if l.Type == "vehicle_journey" {
+			return l.ID
+		}
+	}
+	return ""
 }
 
 // ConnectionsResults holds the results of a departures or arrivals request.
 type ConnectionsResults struct {
 	Connections []Connection
-	Paging      Paging `json:"links"`
+	Disruptions []types.Disruption `json:"disruptions"`
+	Paging      Paging             `json:"links"`
+	Context     types.Context      `json:"context"`
 	Logging     `json:"-"`
+
+	session *Session
+}
+
+// Count returns the number of results available in a ConnectionsResults
+func (cr *ConnectionsResults) Count() int {
+	return len(cr.Connections)
 }
 
 // UnmarshalJSON implements unmarshalling for ConnectionsResults.
@@ -32,19 +66,23 @@ func (cr *ConnectionsResults) UnmarshalJSON(b []byte) error {
 	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
 	data := &struct {
 		// Pointers to the corresponding real values
-		Paging *Paging `json:"links"`
+		Paging      *Paging             `json:"links"`
+		Context     *types.Context      `json:"context"`
+		Disruptions *[]types.Disruption `json:"disruptions"`
 
 		// Value to process
 		Departures *[]Connection `json:"departures"`
 		Arrivals   *[]Connection `json:"arrivals"`
 	}{
-		Paging: &cr.Paging,
+		Paging:      &cr.Paging,
+		Context:     &cr.Context,
+		Disruptions: &cr.Disruptions,
 	}
 
 	// Now unmarshall the raw data into the analogous structure
 	err := json.Unmarshal(b, data)
 	if err != nil {
-		return errors.Wrap(err, "ConnectionsResults.UnmarshalJSON: error while unmarshalling Line")
+		return errors.Wrap(err, "ConnectionsResults.UnmarshalJSON: error while unmarshalling ConnectionsResults")
 	}
 
 	// Now process the values
@@ -56,10 +94,35 @@ func (cr *ConnectionsResults) UnmarshalJSON(b []byte) error {
 	}
 	// else there's nor Departures nor Arrivals found
 
+	// Locate the stop date times in the time zone of their stop point, or else of the region
+	for i := range cr.Connections {
+		c := &cr.Connections[i]
+		loc := c.StopPoint.TimeLocation()
+		if loc == nil {
+			loc = cr.Context.Location()
+		}
+		c.StopDateTime.Localize(loc)
+	}
+
 	return nil
 }
 
-// ConnectionsRequest contains the optional parameters for a Departures request.
+// A DirectionType filters the connections on the direction of their route
+type DirectionType string
+
+// DirectionXXX are the known direction types
+const (
+	// DirectionAll keeps the connections whatever their direction, this is the default
+	DirectionAll DirectionType = "all"
+
+	// DirectionForward keeps the connections on forward routes
+	DirectionForward DirectionType = "forward"
+
+	// DirectionBackward keeps the connections on backward routes
+	DirectionBackward DirectionType = "backward"
+)
+
+// ConnectionsRequest contains the optional parameters for a departures or arrivals request.
 type ConnectionsRequest struct {
 	// From what time on do you want to see the results ?
 	From time.Time
@@ -76,14 +139,29 @@ type ConnectionsRequest struct {
 	// Freshness of the data
 	Freshness types.DataFreshness
 
+	// Depth of the objects in the reply, from 0 to 3. If nil, the server's default of 1 is used
+	Depth *uint
+
+	// Only keep the connections of vehicle journeys running on this calendar
+	Calendar types.ID
+
+	// Only keep the connections of routes in this direction
+	DirectionType DirectionType
+
 	// Enables GeoJSON data in the reply. GeoJSON objects can be VERY large ! >1MB.
 	Geo bool
+
+	// Language of the texts of the reply, such as the names of the places, if the instance supports it
+	Language language.Tag
 }
 
 func (req ConnectionsRequest) toURL() (url.Values, error) {
 	rb := utils.NewRequestBuilder()
 
 	rb.AddDateTime("datetime", req.From)
+	if req.Duration != 0 {
+		rb.AddInt("duration", int(req.Duration/time.Second))
+	}
 
 	// If count is defined don't bother with the minimimal and maximum amount of items to return
 	if req.Count != 0 {
@@ -96,11 +174,19 @@ func (req ConnectionsRequest) toURL() (url.Values, error) {
 	// Set the freshness
 	rb.AddString("data_freshness", string(req.Freshness))
 
+	if req.Depth != nil {
+		rb.AddUInt("depth", *req.Depth)
+	}
+	rb.AddString("calendar", string(req.Calendar))
+	rb.AddString("direction_type", string(req.DirectionType))
+
 	// Add GEO
 	if !req.Geo {
 		rb.AddString("disable_geojson", "true")
 	}
 
+	rb.AddLanguage("language", req.Language)
+
 	return rb.Values(), nil
 }
 
@@ -108,3 +194,32 @@ const (
 	departuresEndpoint string = "departures"
 	arrivalsEndpoint          = "arrivals"
 )
+
+// objectCollections maps the types of the objects having departures & arrivals to their collection in the API
+var objectCollections = map[string]string{
+	"stop_area":       "stop_areas",
+	"stop_point":      "stop_points",
+	"line":            "lines",
+	"route":           "routes",
+	"network":         "networks",
+	"commercial_mode": "commercial_modes",
+	"physical_mode":   "physical_modes",
+	"company":         "companies",
+}
+
+// objectPath returns the path of the given object in a region, such as "stop_areas/stop_area:OIF:SA:59346".
+// Coordinates (formatted as "lon;lat") are accepted too.
+func objectPath(object types.ID) (string, error) {
+	if err := object.Check(); err != nil {
+		return "", err
+	}
+	if _, err := types.ParseCoordinates(object); err == nil {
+		return "coords/" + string(object), nil
+	}
+
+	collection, ok := objectCollections[object.Type()]
+	if !ok {
+		return "", errors.Errorf("unsupported object %q: departures & arrivals are only available for stop areas, stop points, lines, routes, networks, modes, companies & coordinates", object)
+	}
+	return collection + "/" + string(object), nil
+}
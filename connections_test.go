@@ -2,9 +2,15 @@ package navitia
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
+
+	"golang.org/x/text/language"
 
 	"github.com/govitia/navitia/types"
 )
@@ -28,20 +34,20 @@ func TestConnectionsSA(t *testing.T) {
 
 	// Create the run function generator, allowing us to run this in parallel
 	//
-	// Creates two versions: one calling DeparturesSA the other ArrivalsSA
+	// Creates two versions: one calling Departures the other Arrivals
 	rgen := func(region types.ID, resource types.ID) (func(t *testing.T), func(t *testing.T)) {
 		depFunc := func(t *testing.T) {
-			res, err := testSession.Scope(region).DeparturesSA(ctx, req, resource)
+			res, err := testSession.Scope(region).Departures(ctx, req, resource)
 			t.Log(res)
 			if err != nil {
-				t.Errorf("error in DeparturesSA: %v\n\tResource: %s\n\tParameters: %#v\n\tReceived: %#v", err, resource, req, res)
+				t.Errorf("error in Departures: %v\n\tResource: %s\n\tParameters: %#v\n\tReceived: %#v", err, resource, req, res)
 			}
 		}
 		arrFunc := func(t *testing.T) {
-			res, err := testSession.Scope(region).ArrivalsSA(ctx, req, resource)
+			res, err := testSession.Scope(region).Arrivals(ctx, req, resource)
 			t.Log(res)
 			if err != nil {
-				t.Errorf("error in ArrivalsSA: %v\n\tResource: %s\n\tParameters: %#v\n\tReceived: %#v", err, resource, req, res)
+				t.Errorf("error in Arrivals: %v\n\tResource: %s\n\tParameters: %#v\n\tReceived: %#v", err, resource, req, res)
 			}
 		}
 		return depFunc, arrFunc
@@ -70,3 +76,144 @@ func TestConnectionsSA(t *testing.T) {
 func Test_ConnectionsResults_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["connections"], reflect.TypeOf(ConnectionsResults{}))
 }
+
+// Test_Connection_StopDateTime checks that the stop date times of a connection are decoded, and that the realtime helpers report them correctly.
+func Test_Connection_StopDateTime(t *testing.T) {
+	var res ConnectionsResults
+	err := json.Unmarshal(testData["connections"].correct["shannon.json"], &res)
+	if err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+	if len(res.Connections) == 0 {
+		t.Fatal("no connections decoded")
+	}
+
+	// The times are located in the time zone of the stop area
+	dublin, err := time.LoadLocation("Europe/Dublin")
+	if err != nil {
+		t.Skipf("time zone unavailable: %v", err)
+	}
+	c := res.Connections[0]
+	want := time.Date(2017, time.April, 27, 17, 8, 0, 0, dublin)
+	if !c.StopDateTime.Departure.Equal(want) || c.StopDateTime.Departure.Location().String() != "Europe/Dublin" {
+		t.Errorf("departure: got %v, want %v", c.StopDateTime.Departure, want)
+	}
+	if c.IsRealtime() || c.Delay() != 0 {
+		t.Errorf("expected an on-time base-scheduled connection, got freshness %q & delay %v", c.StopDateTime.DataFreshness, c.Delay())
+	}
+	if got := c.VehicleJourneyID(); got != "vehicle_journey:OEA:6751vn10-132-e16-123I-1" {
+		t.Errorf("vehicle journey ID: got %q", got)
+	}
+
+	// Now with a delayed realtime connection
+	raw := []byte(`{
+		"stop_date_time": {
+			"base_departure_date_time": "20170427T170800",
+			"departure_date_time": "20170427T171300",
+			"data_freshness": "realtime"
+		}
+	}`)
+	var delayed Connection
+	err = json.Unmarshal(raw, &delayed)
+	if err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+	if !delayed.IsRealtime() {
+		t.Error("expected a realtime connection")
+	}
+	if got := delayed.Delay(); got != 5*time.Minute {
+		t.Errorf("delay: got %v, want %v", got, 5*time.Minute)
+	}
+}
+
+// Test_ConnectionsRequest_toURL checks that every parameter of a ConnectionsRequest is encoded
+func Test_ConnectionsRequest_toURL(t *testing.T) {
+	t.Parallel()
+
+	depth := uint(0)
+	req := ConnectionsRequest{
+		From:          time.Date(2017, time.April, 27, 17, 0, 0, 0, time.UTC),
+		Duration:      2 * time.Hour,
+		Count:         20,
+		Forbidden:     []types.ID{"line:OIF:1"},
+		Freshness:     types.DataFreshnessRealTime,
+		Depth:         &depth,
+		Calendar:      "calendar:week",
+		DirectionType: DirectionForward,
+		Language:      language.French,
+	}
+	values, err := req.toURL()
+	if err != nil {
+		t.Fatalf("error in ConnectionsRequest.toURL: %v", err)
+	}
+
+	want := map[string]string{
+		"datetime":         "20170427T170000",
+		"duration":         "7200",
+		"count":            "20",
+		"forbidden_uris[]": "line:OIF:1",
+		"data_freshness":   "realtime",
+		"depth":            "0",
+		"calendar":         "calendar:week",
+		"direction_type":   "forward",
+		"disable_geojson":  "true",
+		"language":         "fr",
+	}
+	for key, value := range want {
+		if got := values.Get(key); got != value {
+			t.Errorf("parameter %s: got %q, want %q", key, got, value)
+		}
+	}
+}
+
+// TestScope_Departures checks that departures & arrivals are requested on the path of the given object
+func TestScope_Departures(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte(`{"departures": []}`))
+	}))
+	defer srv.Close()
+
+	s, err := NewCustom("key", srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("error in NewCustom: %v", err)
+	}
+	scope := s.Scope("fr-idf")
+	ctx := context.Background()
+
+	tests := []struct {
+		object types.ID
+		path   string
+	}{
+		{"stop_area:OIF:SA:59346", "/coverage/fr-idf/stop_areas/stop_area:OIF:SA:59346/departures"},
+		{"line:OIF:100110001:1OIF439", "/coverage/fr-idf/lines/line:OIF:100110001:1OIF439/departures"},
+		{types.Coordinates{Longitude: 2.37, Latitude: 48.84}.ID(), "/coverage/fr-idf/coords/2.370;48.840/departures"},
+	}
+	for _, tt := range tests {
+		if _, err := scope.Departures(ctx, ConnectionsRequest{}, tt.object); err != nil {
+			t.Fatalf("error in Departures(%s): %v", tt.object, err)
+		}
+		if path != tt.path {
+			t.Errorf("Departures(%s): expected request to %s, got %s", tt.object, tt.path, path)
+		}
+	}
+
+	if _, err := scope.Arrivals(ctx, ConnectionsRequest{}, "route:OIF:800:1"); err != nil {
+		t.Fatalf("error in Arrivals: %v", err)
+	}
+	if want := "/coverage/fr-idf/routes/route:OIF:800:1/arrivals"; path != want {
+		t.Errorf("Arrivals: expected request to %s, got %s", want, path)
+	}
+
+	// Objects without departures are rejected without querying the server
+	for _, object := range []types.ID{"", "vehicle_journey:OIF:1"} {
+		path = ""
+		if _, err := scope.Departures(ctx, ConnectionsRequest{}, object); err == nil {
+			t.Errorf("Departures(%q): expected an error", object)
+		}
+		if path != "" {
+			t.Errorf("Departures(%q): unexpected request to %s", object, path)
+		}
+	}
+}
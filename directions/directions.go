@@ -0,0 +1,117 @@
+// Package directions turns the path of street network sections into turn-by-turn instructions,
+// such as "Turn left onto Rue du Caire, walk 120 m", in English or French.
+package directions
+
+import (
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// A Turn is the manoeuvre at the beginning of a path segment
+type Turn int
+
+// These are the turns, from the angle between two segments
+const (
+	Depart Turn = iota // Beginning of the path
+	Straight
+	SlightLeft
+	Left
+	SharpLeft
+	SlightRight
+	Right
+	SharpRight
+	UTurn
+)
+
+// TurnOf returns the turn corresponding to the angle in degrees between two segments, as given by types.PathSegment.Direction:
+// negative angles turn left, positive ones right.
+func TurnOf(angle int) Turn {
+	a := angle
+	if a < 0 {
+		a = -a
+	}
+
+	switch {
+	case a <= 10:
+		return Straight
+	case a >= 170:
+		return UTurn
+	}
+
+	var turns [3]Turn
+	if angle < 0 {
+		turns = [...]Turn{SlightLeft, Left, SharpLeft}
+	} else {
+		turns = [...]Turn{SlightRight, Right, SharpRight}
+	}
+	switch {
+	case a <= 45:
+		return turns[0]
+	case a <= 135:
+		return turns[1]
+	default:
+		return turns[2]
+	}
+}
+
+// An Instruction is a step of a path: a turn, then a walk along a street
+type Instruction struct {
+	Turn     Turn
+	Street   string // Name of the street, may be empty
+	Length   uint   // In meters
+	Duration time.Duration
+}
+
+// MergeLength is the length in meters under which a segment is merged into the previous one,
+// as such tiny segments are mostly artifacts of the street network
+var MergeLength uint = 20
+
+// Instructions converts a path into instructions.
+//
+// Segments following the previous one straight on the same street, and segments shorter than MergeLength,
+// are merged into the previous instruction. The turn of a short segment isn't lost though: it is carried
+// forward to the next segment if that one goes straight, along with its street if the next one has no name.
+func Instructions(path []types.PathSegment) []Instruction {
+	var (
+		instructions []Instruction
+		carried      *Instruction // Turn & street of the last short turning segment, to be carried forward
+	)
+	for i, ps := range path {
+		turn := TurnOf(ps.Direction)
+		if i == 0 {
+			turn = Depart
+		}
+
+		street := ps.Name
+		if carried != nil && turn == Straight {
+			turn = carried.Turn
+			if street == "" {
+				street = carried.Street
+			}
+		}
+		carried = nil
+
+		if n := len(instructions); n != 0 {
+			last := &instructions[n-1]
+			if ps.Length < MergeLength || (turn == Straight && street == last.Street) {
+				last.Length += ps.Length
+				last.Duration += ps.Duration
+				if turn != Straight {
+					carried = &Instruction{Turn: turn, Street: street}
+				} else if last.Street == "" {
+					last.Street = street
+				}
+				continue
+			}
+		}
+
+		instructions = append(instructions, Instruction{
+			Turn:     turn,
+			Street:   street,
+			Length:   ps.Length,
+			Duration: ps.Duration,
+		})
+	}
+	return instructions
+}
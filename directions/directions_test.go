@@ -0,0 +1,109 @@
+package directions
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/govitia/navitia/types"
+)
+
+// TestTurnOf checks the mapping of angles to turns
+func TestTurnOf(t *testing.T) {
+	tests := map[int]Turn{
+		0:    Straight,
+		-10:  Straight,
+		30:   SlightRight,
+		-30:  SlightLeft,
+		90:   Right,
+		-90:  Left,
+		150:  SharpRight,
+		-150: SharpLeft,
+		180:  UTurn,
+		-175: UTurn,
+	}
+	for angle, want := range tests {
+		if got := TurnOf(angle); got != want {
+			t.Errorf("TurnOf(%d): got %d, want %d", angle, got, want)
+		}
+	}
+}
+
+// testPath goes along Rue du Caire, with a tiny segment & a straight continuation on the same street to be merged
+var testPath = []types.PathSegment{
+	{Name: "Rue d'Aboukir", Length: 80, Duration: time.Minute, Direction: 0},
+	{Name: "", Length: 5, Duration: 4 * time.Second, Direction: 45},
+	{Name: "Rue du Caire", Length: 100, Duration: time.Minute, Direction: -90},
+	{Name: "Rue du Caire", Length: 20, Duration: 15 * time.Second, Direction: 5},
+	{Name: "Boulevard de Sébastopol", Length: 1450, Duration: 18 * time.Minute, Direction: 100},
+}
+
+// TestInstructions checks the merging of segments
+func TestInstructions(t *testing.T) {
+	want := []Instruction{
+		{Turn: Depart, Street: "Rue d'Aboukir", Length: 85, Duration: 64 * time.Second},
+		{Turn: Left, Street: "Rue du Caire", Length: 120, Duration: 75 * time.Second},
+		{Turn: Right, Street: "Boulevard de Sébastopol", Length: 1450, Duration: 18 * time.Minute},
+	}
+	if got := Instructions(testPath); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+// TestInstructions_ShortTurn checks that the turns of short segments are carried forward, rather than lost in the merge
+func TestInstructions_ShortTurn(t *testing.T) {
+	path := []types.PathSegment{
+		{Name: "Rue Réaumur", Length: 200, Duration: 150 * time.Second, Direction: 0},
+		{Name: "Rue Saint-Denis", Length: 15, Duration: 10 * time.Second, Direction: 90},
+		{Name: "Rue Saint-Denis", Length: 150, Duration: 2 * time.Minute, Direction: 0},
+		{Name: "", Length: 10, Duration: 8 * time.Second, Direction: -90},
+		{Name: "", Length: 5, Duration: 4 * time.Second, Direction: 0},
+		{Name: "Rue de la Cossonnerie", Length: 60, Duration: 45 * time.Second, Direction: 0},
+	}
+	want := []Instruction{
+		{Turn: Depart, Street: "Rue Réaumur", Length: 215, Duration: 160 * time.Second},
+		{Turn: Right, Street: "Rue Saint-Denis", Length: 165, Duration: 132 * time.Second},
+		{Turn: Left, Street: "Rue de la Cossonnerie", Length: 60, Duration: 45 * time.Second},
+	}
+	if got := Instructions(path); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+// TestLocalizer_Section checks the texts in English & French
+func TestLocalizer_Section(t *testing.T) {
+	var s types.Section
+	s.Path = testPath
+
+	tests := []struct {
+		lang language.Tag
+		want []string
+	}{
+		{language.English, []string{
+			"Head onto Rue d'Aboukir, walk 85 m",
+			"Turn left onto Rue du Caire, walk 120 m",
+			"Turn right onto Boulevard de Sébastopol, walk 1.4 km",
+		}},
+		{language.MustParse("fr-CA"), []string{
+			"Prenez Rue d'Aboukir, marchez 85 m",
+			"Tournez à gauche sur Rue du Caire, marchez 120 m",
+			"Tournez à droite sur Boulevard de Sébastopol, marchez 1,4 km",
+		}},
+		{language.German, []string{
+			"Head onto Rue d'Aboukir, walk 85 m",
+			"Turn left onto Rue du Caire, walk 120 m",
+			"Turn right onto Boulevard de Sébastopol, walk 1.4 km",
+		}},
+	}
+	for _, test := range tests {
+		if got := NewLocalizer(test.lang).Section(s); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %q, want %q", test.lang, got, test.want)
+		}
+	}
+
+	if got := NewLocalizer(language.French).Text(Instruction{Turn: UTurn}); got != "Faites demi-tour" {
+		t.Errorf("unexpected text for an unnamed street: %q", got)
+	}
+}
@@ -0,0 +1,151 @@
+package directions
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/govitia/navitia/types"
+)
+
+// templates holds the texts of a language
+type templates struct {
+	onto    map[Turn]string // Turn onto a named street, with its name
+	unnamed map[Turn]string // Turn onto an unnamed street
+	walk    string          // Walk a distance
+	arrive  string          // Arrival
+}
+
+// texts holds the templates of the supported languages, the first one being the default
+var texts = []struct {
+	tag language.Tag
+	templates
+}{
+	{language.English, templates{
+		onto: map[Turn]string{
+			Depart:      "Head onto %s",
+			Straight:    "Continue onto %s",
+			SlightLeft:  "Turn slightly left onto %s",
+			Left:        "Turn left onto %s",
+			SharpLeft:   "Turn sharp left onto %s",
+			SlightRight: "Turn slightly right onto %s",
+			Right:       "Turn right onto %s",
+			SharpRight:  "Turn sharp right onto %s",
+			UTurn:       "Make a U-turn onto %s",
+		},
+		unnamed: map[Turn]string{
+			Depart:      "Head off",
+			Straight:    "Continue straight",
+			SlightLeft:  "Turn slightly left",
+			Left:        "Turn left",
+			SharpLeft:   "Turn sharp left",
+			SlightRight: "Turn slightly right",
+			Right:       "Turn right",
+			SharpRight:  "Turn sharp right",
+			UTurn:       "Make a U-turn",
+		},
+		walk:   "walk %s",
+		arrive: "You have arrived at %s",
+	}},
+	{language.French, templates{
+		onto: map[Turn]string{
+			Depart:      "Prenez %s",
+			Straight:    "Continuez sur %s",
+			SlightLeft:  "Tournez légèrement à gauche sur %s",
+			Left:        "Tournez à gauche sur %s",
+			SharpLeft:   "Tournez franchement à gauche sur %s",
+			SlightRight: "Tournez légèrement à droite sur %s",
+			Right:       "Tournez à droite sur %s",
+			SharpRight:  "Tournez franchement à droite sur %s",
+			UTurn:       "Faites demi-tour sur %s",
+		},
+		unnamed: map[Turn]string{
+			Depart:      "Partez",
+			Straight:    "Continuez tout droit",
+			SlightLeft:  "Tournez légèrement à gauche",
+			Left:        "Tournez à gauche",
+			SharpLeft:   "Tournez franchement à gauche",
+			SlightRight: "Tournez légèrement à droite",
+			Right:       "Tournez à droite",
+			SharpRight:  "Tournez franchement à droite",
+			UTurn:       "Faites demi-tour",
+		},
+		walk:   "marchez %s",
+		arrive: "Vous êtes arrivé à %s",
+	}},
+}
+
+// matcher matches the requested languages with the supported ones
+var matcher = language.NewMatcher(Languages())
+
+// Languages returns the languages supported
+func Languages() []language.Tag {
+	tags := make([]language.Tag, len(texts))
+	for i, t := range texts {
+		tags[i] = t.tag
+	}
+	return tags
+}
+
+// A Localizer writes instructions in a language
+type Localizer struct {
+	tag       language.Tag
+	templates templates
+	printer   *message.Printer
+}
+
+// NewLocalizer returns a localizer for the best supported match of the given language, English being the default.
+func NewLocalizer(lang language.Tag) *Localizer {
+	_, i, _ := matcher.Match(lang)
+	return &Localizer{
+		tag:       texts[i].tag,
+		templates: texts[i].templates,
+		printer:   message.NewPrinter(texts[i].tag),
+	}
+}
+
+// Language returns the language used by the localizer
+func (l *Localizer) Language() language.Tag {
+	return l.tag
+}
+
+// Text returns the text of the instruction, such as "Turn left onto Rue du Caire, walk 120 m"
+func (l *Localizer) Text(i Instruction) string {
+	var text string
+	if i.Street != "" {
+		text = fmt.Sprintf(l.templates.onto[i.Turn], i.Street)
+	} else {
+		text = l.templates.unnamed[i.Turn]
+	}
+	if i.Length != 0 {
+		text += ", " + fmt.Sprintf(l.templates.walk, l.Distance(i.Length))
+	}
+	return text
+}
+
+// Distance formats a distance in meters, such as "120 m" or "1.5 km" (or "1,5 km" in French)
+func (l *Localizer) Distance(meters uint) string {
+	if meters < 1000 {
+		return l.printer.Sprintf("%d m", meters)
+	}
+	return l.printer.Sprintf("%.1f km", float64(meters)/1000)
+}
+
+// Section returns the instructions of a street network section, ending with the arrival at its destination.
+// It returns nil for sections without a path.
+func (l *Localizer) Section(s types.Section) []string {
+	if len(s.Path) == 0 {
+		return nil
+	}
+
+	instructions := Instructions(s.Path)
+	texts := make([]string, 0, len(instructions)+1)
+	for _, i := range instructions {
+		texts = append(texts, l.Text(i))
+	}
+	if s.To.Name != "" {
+		texts = append(texts, fmt.Sprintf(l.templates.arrive, s.To.Name))
+	}
+	return texts
+}
@@ -4,8 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
-	"github.com/pkg/errors"
+	"github.com/govitia/navitia/types"
 )
 
 // RemoteErrorID is an ID for a remote error
@@ -71,12 +72,38 @@ func parseRemoteError(resp *http.Response) error {
 	remoteErr := &RemoteError{StatusCode: resp.StatusCode}
 
 	// Parse it
+	// If the body isn't JSON (for example when a proxy answers in place of the server), we still return a RemoteError
+	// so that the status code is available, using the status text as a message.
 	dec := json.NewDecoder(resp.Body)
 	err := dec.Decode(&remoteErr)
 	if err != nil {
-		return errors.Wrap(err, "parseRemoteError: error while decoding JSON")
+		remoteErr.Message = http.StatusText(resp.StatusCode)
 	}
 
 	// Return
 	return remoteErr
 }
+
+// OutOfProductionError is returned by a Scope checking the production period of its region,
+// when a request date is outside of it.
+type OutOfProductionError struct {
+	Region types.ID  // The region of the scope
+	Param  string    // The parameter holding the date, such as "datetime" or "since"
+	Date   time.Time // The date given
+
+	// The production period of the region, both days included
+	Start time.Time
+	End   time.Time
+}
+
+// Error satisfies the error interface
+func (err OutOfProductionError) Error() string {
+	return fmt.Sprintf(
+		"%s (%s) is outside of the production period of region %s: valid from %s to %s",
+		err.Param,
+		err.Date.Format(types.DateTimeFormat),
+		err.Region,
+		err.Start.Format(types.DateFormat),
+		err.End.Format(types.DateFormat),
+	)
+}
@@ -0,0 +1,112 @@
+package geojson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twpayne/go-geom"
+
+	"github.com/govitia/navitia"
+	"github.com/govitia/navitia/types"
+)
+
+// testJourneyResults holds a journey walking to the metro 14, transferring to the RER A
+const testJourneyResults = `{
+	"journeys": [{
+		"duration": 1500,
+		"sections": [
+			{
+				"id": "section_0", "type": "street_network", "mode": "walking", "duration": 300,
+				"geojson": {"type": "LineString", "coordinates": [[2.3700, 48.8400], [2.3720, 48.8410]]}
+			},
+			{
+				"id": "section_1", "type": "public_transport", "duration": 600,
+				"display_informations": {"code": "14", "label": "14", "color": "62259D", "network": "RATP"},
+				"from": {"id": "stop_point:1", "name": "Bercy", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:1", "coord": {"lon": "2.3720", "lat": "48.8410"}}},
+				"to": {"id": "stop_point:2", "name": "Gare de Lyon", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:2", "coord": {"lon": "2.3730", "lat": "48.8440"}}}
+			},
+			{
+				"id": "section_2", "type": "transfer", "mode": "walking", "duration": 300,
+				"from": {"id": "stop_point:2", "name": "Gare de Lyon", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:2", "coord": {"lon": "2.3730", "lat": "48.8440"}}},
+				"to": {"id": "stop_point:3", "name": "Gare de Lyon RER", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:3", "coord": {"lon": "2.3735", "lat": "48.8445"}}}
+			},
+			{
+				"id": "section_3", "type": "public_transport", "duration": 300,
+				"display_informations": {"code": "A", "label": "A", "color": "E2231A", "network": "RER"},
+				"from": {"id": "stop_point:3", "name": "Gare de Lyon RER", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:3", "coord": {"lon": "2.3735", "lat": "48.8445"}}},
+				"to": {"id": "stop_point:4", "name": "Châtelet", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:4", "coord": {"lon": "2.3470", "lat": "48.8580"}}}
+			}
+		]
+	}]
+}`
+
+// TestJourneyResults checks the features of the journeys: sections styled with their line color, and transfers
+func TestJourneyResults(t *testing.T) {
+	var res navitia.JourneyResults
+	if err := json.Unmarshal([]byte(testJourneyResults), &res); err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+
+	fc := JourneyResults(&res)
+	if got := len(fc.Features); got != 5 {
+		t.Fatalf("expected 4 sections & 1 transfer, got %d features", got)
+	}
+
+	walk, metro := fc.Features[0], fc.Features[1]
+	if walk.Properties["stroke"] != DefaultStroke || walk.Properties["mode"] != "walking" || walk.Properties["journey"] != 0 {
+		t.Errorf("unexpected properties of the walk: %v", walk.Properties)
+	}
+	if metro.Properties["stroke"] != "#62259d" || metro.Properties["code"] != "14" || metro.Properties["duration"] != 600 {
+		t.Errorf("unexpected properties of the metro: %v", metro.Properties)
+	}
+
+	transfer := fc.Features[4]
+	if !transfer.Geometry.IsPoint() || transfer.Properties["feature_type"] != "transfer" || transfer.Properties["name"] != "Gare de Lyon RER" {
+		t.Errorf("unexpected transfer feature: %+v", transfer)
+	}
+
+	if _, err := json.Marshal(fc); err != nil {
+		t.Errorf("error while marshalling: %v", err)
+	}
+}
+
+// TestPlacesResults checks that places are exported as points, skipping those without coordinates
+func TestPlacesResults(t *testing.T) {
+	var res navitia.PlacesResults
+	err := json.Unmarshal([]byte(`{"places": [
+		{"id": "stop_area:1", "name": "Gare de Lyon", "embedded_type": "stop_area", "quality": 90, "stop_area": {"id": "stop_area:1", "coord": {"lon": "2.3730", "lat": "48.8440"}}},
+		{"id": "line:1", "name": "Line 14", "embedded_type": "line", "line": {"id": "line:1"}}
+	]}`), &res)
+	if err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+
+	fc := PlacesResults(&res)
+	if len(fc.Features) != 1 {
+		t.Fatalf("expected one feature, got %d", len(fc.Features))
+	}
+	f := fc.Features[0]
+	if f.ID != "stop_area:1" || f.Geometry.Point[0] != 2.373 || f.Properties["embedded_type"] != "stop_area" {
+		t.Errorf("unexpected feature: %+v", f)
+	}
+}
+
+// TestRegionResults checks the export of the shapes of regions
+func TestRegionResults(t *testing.T) {
+	shape := geom.NewMultiPolygon(geom.XY)
+	if err := shape.Push(geom.NewPolygonFlat(geom.XY, []float64{2, 48, 3, 48, 3, 49, 2, 49, 2, 48}, []int{10})); err != nil {
+		t.Fatal(err)
+	}
+	res := navitia.RegionResults{Regions: []types.Region{
+		{ID: "fr-idf", Name: "Île-de-France", Shape: shape},
+		{ID: "fr-ne", Name: "Nord-Est"},
+	}}
+
+	fc := RegionResults(&res)
+	if len(fc.Features) != 1 {
+		t.Fatalf("expected one feature, got %d", len(fc.Features))
+	}
+	if f := fc.Features[0]; !f.Geometry.IsMultiPolygon() || len(f.Geometry.MultiPolygon[0][0]) != 5 || f.ID != "fr-idf" {
+		t.Errorf("unexpected feature: %+v", f)
+	}
+}
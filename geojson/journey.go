@@ -0,0 +1,123 @@
+// Package geojson exports journeys, places and regions as GeoJSON FeatureCollections, ready for map rendering.
+//
+// The features follow the simplestyle specification (https://github.com/mapbox/simplestyle-spec) for their styling,
+// so that sections are drawn with the color of their line.
+package geojson
+
+import (
+	"github.com/paulmach/go.geojson"
+	"github.com/twpayne/go-geom"
+
+	"github.com/govitia/navitia"
+	"github.com/govitia/navitia/shape"
+	"github.com/govitia/navitia/types"
+)
+
+// DefaultStroke is the color of the sections without a line color, such as walking ones
+var DefaultStroke = "#555555"
+
+// Section returns a LineString feature of the section, or nil if it has no line (see shape.Section).
+//
+// Its properties are the "section_type", "mode", "duration" (in seconds), and for public transport sections,
+// the "code", "label", "network" & "direction" of the line. It is styled with the "stroke" of the line color.
+func Section(s types.Section) *geojson.Feature {
+	ls := shape.Section(s)
+	if ls == nil {
+		return nil
+	}
+
+	f := geojson.NewLineStringFeature(coordinates(ls))
+	if s.ID != "" {
+		f.ID = string(s.ID)
+	}
+	f.SetProperty("section_type", string(s.Type))
+	if s.Mode != "" {
+		f.SetProperty("mode", s.Mode)
+	}
+	f.SetProperty("duration", int(s.Duration.Seconds()))
+
+	stroke := DefaultStroke
+	if s.Type == types.SectionPublicTransport {
+		f.SetProperty("code", s.Display.Code)
+		f.SetProperty("label", s.Display.Label)
+		f.SetProperty("network", s.Display.Network)
+		f.SetProperty("direction", s.Display.Direction)
+		if c := types.HexColor(s.Display.Color); c != "" {
+			stroke = c
+		}
+	}
+	f.SetProperty("stroke", stroke)
+	return f
+}
+
+// Journey returns a FeatureCollection of the journey: a LineString feature for each of its sections, see Section,
+// and a Point feature for each transfer, where the traveller boards a vehicle after having left another one.
+func Journey(j types.Journey) *geojson.FeatureCollection {
+	fc := geojson.NewFeatureCollection()
+	addJourney(fc, j, -1)
+	return fc
+}
+
+// JourneyResults returns a single FeatureCollection of all the journeys of the results, see Journey.
+// Each feature has a "journey" property holding the index of its journey.
+func JourneyResults(res *navitia.JourneyResults) *geojson.FeatureCollection {
+	fc := geojson.NewFeatureCollection()
+	for i, j := range res.Journeys {
+		addJourney(fc, j, i)
+	}
+	return fc
+}
+
+// addJourney adds the features of the journey to the collection, with their journey index if positive
+func addJourney(fc *geojson.FeatureCollection, j types.Journey, index int) {
+	var (
+		features []*geojson.Feature
+		boarded  bool
+	)
+	for i := range j.Sections {
+		s := &j.Sections[i]
+		if f := Section(*s); f != nil {
+			features = append(features, f)
+		}
+
+		if s.Type != types.SectionPublicTransport {
+			continue
+		}
+		if boarded {
+			if f := transfer(s); f != nil {
+				features = append(features, f)
+			}
+		}
+		boarded = true
+	}
+
+	for _, f := range features {
+		if index >= 0 {
+			f.SetProperty("journey", index)
+		}
+		fc.AddFeature(f)
+	}
+}
+
+// transfer returns a Point feature of the transfer to the given public transport section, at its origin
+func transfer(s *types.Section) *geojson.Feature {
+	c, ok := s.From.Coord()
+	if !ok {
+		return nil
+	}
+	f := geojson.NewPointFeature([]float64{c.Longitude, c.Latitude})
+	f.SetProperty("feature_type", "transfer")
+	f.SetProperty("name", s.From.Name)
+	f.SetProperty("marker-symbol", "rail")
+	return f
+}
+
+// coordinates returns the positions of the line
+func coordinates(ls *geom.LineString) [][]float64 {
+	positions := make([][]float64, ls.NumCoords())
+	for i := range positions {
+		c := ls.Coord(i)
+		positions[i] = []float64{c.X(), c.Y()}
+	}
+	return positions
+}
@@ -0,0 +1,75 @@
+package geojson
+
+import (
+	"github.com/paulmach/go.geojson"
+
+	"github.com/govitia/navitia"
+	"github.com/govitia/navitia/types"
+)
+
+// Container returns a Point feature of the place held by the container, or nil if it holds no place with coordinates.
+// Its properties are its "name", "embedded_type" & "quality".
+func Container(c *types.Container) *geojson.Feature {
+	coord, ok := c.Coord()
+	if !ok {
+		return nil
+	}
+
+	f := geojson.NewPointFeature([]float64{coord.Longitude, coord.Latitude})
+	f.ID = string(c.ID)
+	f.SetProperty("name", c.Name)
+	f.SetProperty("embedded_type", c.EmbeddedType)
+	f.SetProperty("quality", c.Quality)
+	return f
+}
+
+// PlacesResults returns a FeatureCollection of the places of the results having coordinates, see Container.
+func PlacesResults(res *navitia.PlacesResults) *geojson.FeatureCollection {
+	fc := geojson.NewFeatureCollection()
+	for i := range res.Places {
+		if f := Container(&res.Places[i]); f != nil {
+			fc.AddFeature(f)
+		}
+	}
+	return fc
+}
+
+// Region returns a MultiPolygon feature of the shape of the region, or nil if it has none (as when Geo data wasn't requested).
+// Its properties are its "name" & "status".
+func Region(r types.Region) *geojson.Feature {
+	if r.Shape == nil || r.Shape.NumPolygons() == 0 {
+		return nil
+	}
+
+	polygons := make([][][][]float64, r.Shape.NumPolygons())
+	for i := range polygons {
+		polygon := r.Shape.Polygon(i)
+		rings := make([][][]float64, polygon.NumLinearRings())
+		for j := range rings {
+			ring := polygon.LinearRing(j)
+			rings[j] = make([][]float64, ring.NumCoords())
+			for k := range rings[j] {
+				c := ring.Coord(k)
+				rings[j][k] = []float64{c.X(), c.Y()}
+			}
+		}
+		polygons[i] = rings
+	}
+
+	f := geojson.NewMultiPolygonFeature(polygons...)
+	f.ID = string(r.ID)
+	f.SetProperty("name", r.Name)
+	f.SetProperty("status", r.Status)
+	return f
+}
+
+// RegionResults returns a FeatureCollection of the regions of the results having a shape, see Region.
+func RegionResults(res *navitia.RegionResults) *geojson.FeatureCollection {
+	fc := geojson.NewFeatureCollection()
+	for _, r := range res.Regions {
+		if f := Region(r); f != nil {
+			fc.AddFeature(f)
+		}
+	}
+	return fc
+}
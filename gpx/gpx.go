@@ -0,0 +1,158 @@
+// Package gpx exports journeys as GPX 1.1 documents, for use in GPS tools.
+//
+// Each section of a journey is a track, and its stop points are waypoints with their names & times.
+// Walking sections get a waypoint at their start describing the streets to follow.
+// As GPX requires, times are written in UTC.
+//
+// See https://www.topografix.com/GPX/1/1/
+package gpx
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/govitia/navitia/internal/describe"
+	"github.com/govitia/navitia/shape"
+	"github.com/govitia/navitia/types"
+)
+
+// Namespace of GPX 1.1 documents
+const Namespace = "http://www.topografix.com/GPX/1/1"
+
+// Creator is the creator set in the exported documents
+var Creator = "github.com/govitia/navitia"
+
+// A GPX is a GPX 1.1 document
+type GPX struct {
+	XMLName   xml.Name   `xml:"gpx"`
+	Namespace string     `xml:"xmlns,attr"`
+	Version   string     `xml:"version,attr"`
+	Creator   string     `xml:"creator,attr"`
+	Metadata  *Metadata  `xml:"metadata,omitempty"`
+	Waypoints []Waypoint `xml:"wpt"`
+	Tracks    []Track    `xml:"trk"`
+}
+
+// Metadata describes a GPX document
+type Metadata struct {
+	Name string     `xml:"name,omitempty"`
+	Desc string     `xml:"desc,omitempty"`
+	Time *time.Time `xml:"time,omitempty"`
+}
+
+// A Waypoint is a point of a GPX document, either on its own or as part of a track segment
+type Waypoint struct {
+	Latitude  float64    `xml:"lat,attr"`
+	Longitude float64    `xml:"lon,attr"`
+	Time      *time.Time `xml:"time,omitempty"`
+	Name      string     `xml:"name,omitempty"`
+	Desc      string     `xml:"desc,omitempty"`
+	Type      string     `xml:"type,omitempty"`
+}
+
+// A Track is an ordered list of points, split into segments
+type Track struct {
+	Name     string         `xml:"name,omitempty"`
+	Desc     string         `xml:"desc,omitempty"`
+	Type     string         `xml:"type,omitempty"`
+	Segments []TrackSegment `xml:"trkseg"`
+}
+
+// A TrackSegment is a continuous part of a track
+type TrackSegment struct {
+	Points []Waypoint `xml:"trkpt"`
+}
+
+// Journey exports the journey as a GPX document, with a track per section having a line (see shape.Section),
+// and a waypoint for each stop point served.
+//
+// Navitia's times being wall-clock times, loc is their time zone, usually that of the region. If nil, UTC is used.
+func Journey(j types.Journey, loc *time.Location) *GPX {
+	g := &GPX{
+		Namespace: Namespace,
+		Version:   "1.1",
+		Creator:   Creator,
+		Metadata: &Metadata{
+			Name: describe.Journey(j),
+			Time: timePtr(j.Departure, loc),
+		},
+	}
+
+	for _, s := range j.Sections {
+		g.Waypoints = append(g.Waypoints, waypoints(s, loc)...)
+
+		ls := shape.Section(s)
+		if ls == nil {
+			continue
+		}
+		var seg TrackSegment
+		for i := 0; i < ls.NumCoords(); i++ {
+			c := ls.Coord(i)
+			seg.Points = append(seg.Points, Waypoint{Latitude: c.Y(), Longitude: c.X()})
+		}
+		g.Tracks = append(g.Tracks, Track{
+			Name:     describe.Section(s),
+			Desc:     describe.Ends(s.From.Name, s.To.Name),
+			Type:     describe.Mode(s),
+			Segments: []TrackSegment{seg},
+		})
+	}
+	return g
+}
+
+// Encode writes the document as XML, with its header
+func (g *GPX) Encode(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(g)
+}
+
+// waypoints returns the waypoints of the section: its stop points with their times,
+// or for a street network section, its start with the streets to follow
+func waypoints(s types.Section, loc *time.Location) []Waypoint {
+	var wpts []Waypoint
+	for _, st := range s.StopTimes {
+		c := st.StopPoint.Coord
+		if c == (types.Coordinates{}) {
+			continue
+		}
+		t := st.PTDateTime.Departure
+		if t.IsZero() {
+			t = st.PTDateTime.Arrival
+		}
+		wpts = append(wpts, Waypoint{
+			Latitude:  c.Latitude,
+			Longitude: c.Longitude,
+			Time:      timePtr(t, loc),
+			Name:      st.StopPoint.Name,
+			Type:      "stop_point",
+		})
+	}
+
+	if s.Type == types.SectionStreetNetwork && len(s.Path) != 0 {
+		if c, ok := s.From.Coord(); ok {
+			wpts = append(wpts, Waypoint{
+				Latitude:  c.Latitude,
+				Longitude: c.Longitude,
+				Time:      timePtr(s.Departure, loc),
+				Name:      s.From.Name,
+				Desc:      describe.Path(s.Path),
+				Type:      s.Mode,
+			})
+		}
+	}
+	return wpts
+}
+
+// timePtr returns a pointer to the time in UTC, given as a wall-clock time in loc, or nil if it is zero
+func timePtr(t time.Time, loc *time.Location) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = describe.Time(t, loc).UTC()
+	return &t
+}
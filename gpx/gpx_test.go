@@ -0,0 +1,86 @@
+package gpx
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// testJourney walks to the RER A on a winter evening, and rides it for a stop
+const testJourney = `{
+	"departure_date_time": "20170112T173000",
+	"sections": [
+		{
+			"id": "section_0", "type": "street_network", "mode": "walking", "duration": 120,
+			"departure_date_time": "20170112T173000",
+			"from": {"id": "2.373;48.845", "name": "20 Boulevard Diderot", "embedded_type": "address", "address": {"id": "2.373;48.845", "coord": {"lon": "2.3730", "lat": "48.8450"}}},
+			"to": {"id": "stop_point:1", "name": "Gare de Lyon", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:1", "coord": {"lon": "2.3740", "lat": "48.8440"}}},
+			"path": [{"length": 80, "name": "Boulevard Diderot", "duration": 60, "direction": 0}],
+			"geojson": {"type": "LineString", "coordinates": [[2.3730, 48.8450], [2.3740, 48.8440]]}
+		},
+		{
+			"id": "section_1", "type": "public_transport", "duration": 180,
+			"display_informations": {"code": "A", "commercial_mode": "RER", "direction": "Saint-Germain-en-Laye", "color": "E2231A"},
+			"from": {"id": "stop_point:1", "name": "Gare de Lyon", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:1", "coord": {"lon": "2.3740", "lat": "48.8440"}}},
+			"to": {"id": "stop_point:2", "name": "Châtelet-Les Halles", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:2", "coord": {"lon": "2.3470", "lat": "48.8620"}}},
+			"stop_date_times": [
+				{"stop_point": {"id": "stop_point:1", "name": "Gare de Lyon", "coord": {"lon": "2.3740", "lat": "48.8440"}}, "departure_date_time": "20170112T173200", "arrival_date_time": "20170112T173200"},
+				{"stop_point": {"id": "stop_point:2", "name": "Châtelet-Les Halles", "coord": {"lon": "2.3470", "lat": "48.8620"}}, "departure_date_time": "20170112T173500", "arrival_date_time": "20170112T173500"}
+			]
+		}
+	]
+}`
+
+// TestJourney checks the export of a journey, with its times written in UTC, and that it can be read back
+func TestJourney(t *testing.T) {
+	var j types.Journey
+	if err := json.Unmarshal([]byte(testJourney), &j); err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Journey(j, paris).Encode(&buf); err != nil {
+		t.Fatalf("error in Encode: %v", err)
+	}
+	var g GPX
+	if err := xml.Unmarshal(buf.Bytes(), &g); err != nil {
+		t.Fatalf("error while reading the document back: %v\n%s", err, buf.Bytes())
+	}
+
+	// Paris is an hour ahead of UTC in winter
+	if g.Version != "1.1" || g.Namespace != Namespace || g.Metadata == nil || g.Metadata.Name != "20 Boulevard Diderot → Châtelet-Les Halles" {
+		t.Errorf("unexpected document: %+v", g)
+	}
+	if g.Metadata.Time == nil || !g.Metadata.Time.Equal(time.Date(2017, 1, 12, 16, 30, 0, 0, time.UTC)) {
+		t.Errorf("unexpected document time: %v", g.Metadata.Time)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("<time>2017-01-12T16:35:00Z</time>")) {
+		t.Errorf("expected the arrival at Châtelet-Les Halles in UTC, got\n%s", buf.Bytes())
+	}
+
+	if len(g.Tracks) != 2 {
+		t.Fatalf("expected a track per section, got %d", len(g.Tracks))
+	}
+	if tr := g.Tracks[1]; tr.Name != "RER A to Saint-Germain-en-Laye" || tr.Desc != "Gare de Lyon → Châtelet-Les Halles" || len(tr.Segments) != 1 || len(tr.Segments[0].Points) != 2 {
+		t.Errorf("unexpected public transport track: %+v", tr)
+	}
+
+	// The start of the walk, then the two stop points
+	if len(g.Waypoints) != 3 {
+		t.Fatalf("expected 3 waypoints, got %+v", g.Waypoints)
+	}
+	if w := g.Waypoints[0]; w.Desc != "Boulevard Diderot (80 m)" || w.Type != "walking" {
+		t.Errorf("unexpected walking waypoint: %+v", w)
+	}
+	if w := g.Waypoints[1]; w.Name != "Gare de Lyon" || w.Time == nil || !w.Time.Equal(time.Date(2017, 1, 12, 16, 32, 0, 0, time.UTC)) || w.Latitude != 48.844 {
+		t.Errorf("unexpected stop point waypoint: %+v", w)
+	}
+}
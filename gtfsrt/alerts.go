@@ -0,0 +1,188 @@
+package gtfsrt
+
+import (
+	"strings"
+
+	"github.com/govitia/navitia/types"
+)
+
+// EffectMapping maps the effects of Navitia disruptions to GTFS-Realtime effects.
+// Effects missing from it become UnknownEffect. It may be modified to suit a feed's consumers.
+var EffectMapping = map[types.Effect]Effect{
+	types.EffectNoService:                NoService,
+	types.JourneyStatusReducedService:    ReducedService,
+	types.EffectSignificantDelays:        SignificantDelays,
+	types.JourneyStatusSignificantDelay:  SignificantDelays,
+	types.JourneyStatusDetour:            Detour,
+	types.JourneyStatusAdditionalService: AdditionalService,
+	types.JourneyStatusModifiedService:   ModifiedService,
+	types.JourneyStatusOtherEffect:       OtherEffect,
+	types.JourneyStatusUnknownEffect:     UnknownEffect,
+	types.JourneyStatusStopMoved:         StopMoved,
+}
+
+// severityLevel derives the severity level of an alert from its effect
+func severityLevel(effect Effect) SeverityLevel {
+	switch effect {
+	case NoService, ReducedService:
+		return Severe
+	case SignificantDelays, Detour, StopMoved, ModifiedService:
+		return Warning
+	case AdditionalService, OtherEffect, NoEffect, AccessibilityIssue:
+		return Info
+	default:
+		return UnknownSeverity
+	}
+}
+
+// NewAlertFeed converts the disruptions into a feed of service alerts, one per disruption.
+func NewAlertFeed(disruptions []types.Disruption, opts Options) *FeedMessage {
+	fm := &FeedMessage{
+		Header: opts.header(),
+		Entity: make([]FeedEntity, 0, len(disruptions)),
+	}
+	for _, d := range disruptions {
+		fm.Entity = append(fm.Entity, FeedEntity{
+			ID:    string(d.ID),
+			Alert: NewAlert(d, opts),
+		})
+	}
+	return fm
+}
+
+// NewAlert converts a disruption into a service alert.
+//
+// Its application periods become the active periods, its severity's effect is mapped through EffectMapping, and
+// its impacted objects become the informed entities: networks as agencies, lines & routes as routes, stop areas & stop points as stops,
+// and trips as trips, along with their impacted stops.
+// The title message, if any, becomes the header text, and the first other plain text message the description.
+func NewAlert(d types.Disruption, opts Options) *Alert {
+	effect, ok := EffectMapping[d.Severity.Effect]
+	if !ok {
+		effect = UnknownEffect
+	}
+
+	a := &Alert{
+		Cause:         UnknownCause,
+		Effect:        effect,
+		SeverityLevel: severityLevel(effect),
+	}
+
+	for _, p := range d.Periods {
+		a.ActivePeriod = append(a.ActivePeriod, TimeRange{Start: opts.posix(p.Begin), End: opts.posix(p.End)})
+	}
+
+	a.InformedEntity = informedEntities(d, opts)
+
+	// Now the texts
+	var header, description string
+	for _, m := range d.Messages {
+		switch {
+		case m.Channel != nil && m.Channel.Has(types.ChannelTitle):
+			if header == "" {
+				header = m.Text
+			}
+		case m.IsHTML():
+			// GTFS-Realtime texts are plain text
+		case description == "":
+			description = m.Text
+		}
+	}
+	if header == "" {
+		header, description = description, ""
+	}
+	a.HeaderText = opts.translated(header)
+	a.DescriptionText = opts.translated(description)
+
+	return a
+}
+
+// translated returns the text as a TranslatedString in the options' language, or nil if it is empty
+func (opts Options) translated(text string) *TranslatedString {
+	if text == "" {
+		return nil
+	}
+	return &TranslatedString{Translation: []Translation{{Text: text, Language: opts.Language}}}
+}
+
+// informedEntities returns the entity selectors of the objects impacted by a disruption, each once
+func informedEntities(d types.Disruption, opts Options) []EntitySelector {
+	var selectors []EntitySelector
+	seen := map[EntitySelector]bool{}
+	add := func(es EntitySelector) {
+		// Selectors of a trip hold a pointer to it, so only the others may be deduplicated
+		if es.Trip == nil {
+			if seen[es] {
+				return
+			}
+			seen[es] = true
+		}
+		selectors = append(selectors, es)
+	}
+
+	for i := range d.Impacted {
+		io := &d.Impacted[i]
+		obj := &io.Object
+
+		switch kind := objectKind(obj); kind {
+		case "network":
+			add(EntitySelector{AgencyID: opts.id(obj.ID)})
+		case "line":
+			add(EntitySelector{RouteID: opts.id(obj.ID)})
+		case "route":
+			add(EntitySelector{RouteID: opts.id(routeLine(obj))})
+		case "stop_area", "stop_point":
+			add(EntitySelector{StopID: opts.id(obj.ID)})
+		case "trip", "vehicle_journey":
+			trip := &TripDescriptor{TripID: opts.id(obj.ID)}
+			if len(io.ImpactedStops) == 0 {
+				add(EntitySelector{Trip: trip})
+			}
+			for _, is := range io.ImpactedStops {
+				if is.StopTimeEffect != types.StopTimeUnchanged {
+					add(EntitySelector{Trip: trip, StopID: opts.id(is.Point.ID)})
+				}
+			}
+		}
+
+		// A line section impacts its routes, between its two ends
+		section := io.ImpactedSection
+		for _, r := range section.Routes {
+			id := r.Line.ID
+			if id == "" {
+				id = r.ID
+			}
+			for _, stop := range []types.ID{section.From.ID, section.To.ID} {
+				if stop != "" {
+					add(EntitySelector{RouteID: opts.id(id), StopID: opts.id(stop)})
+				}
+			}
+		}
+	}
+
+	return selectors
+}
+
+// objectKind returns the kind of object held by the container: its embedded type, or else the type given by its ID
+func objectKind(c *types.Container) string {
+	if c.EmbeddedType != "" {
+		return c.EmbeddedType
+	}
+	if t := c.ID.Type(); t != "" {
+		return t
+	}
+	if strings.HasPrefix(string(c.ID), "vehicle_journey:") {
+		return "vehicle_journey"
+	}
+	return ""
+}
+
+// routeLine returns the ID of the line of an impacted route, as GTFS routes are Navitia lines, or the route's ID if its line is unknown
+func routeLine(c *types.Container) types.ID {
+	if obj, err := c.Object(); err == nil {
+		if r, ok := obj.(*types.Route); ok && r.Line.ID != "" {
+			return r.Line.ID
+		}
+	}
+	return c.ID
+}
@@ -0,0 +1,172 @@
+package gtfsrt
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// wireField is a field decoded from the protobuf wire format, for testing purposes
+type wireField struct {
+	num   int
+	value uint64 // For varints
+	bytes []byte // For length-delimited fields
+}
+
+// decodeWire decodes the fields of a message, failing the test if it isn't well-formed
+func decodeWire(t *testing.T, b []byte) []wireField {
+	t.Helper()
+	varint := func() uint64 {
+		var v uint64
+		for shift := uint(0); ; shift += 7 {
+			if len(b) == 0 {
+				t.Fatal("truncated varint")
+			}
+			c := b[0]
+			b = b[1:]
+			v |= uint64(c&0x7f) << shift
+			if c < 0x80 {
+				return v
+			}
+		}
+	}
+
+	var fields []wireField
+	for len(b) != 0 {
+		key := varint()
+		f := wireField{num: int(key >> 3)}
+		switch key & 7 {
+		case wireVarint:
+			f.value = varint()
+		case wireBytes:
+			n := varint()
+			if uint64(len(b)) < n {
+				t.Fatal("truncated field")
+			}
+			f.bytes, b = b[:n], b[n:]
+		default:
+			t.Fatalf("unexpected wire type %d", key&7)
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// field returns the fields with the given number
+func field(fields []wireField, num int) []wireField {
+	var found []wireField
+	for _, f := range fields {
+		if f.num == num {
+			found = append(found, f)
+		}
+	}
+	return found
+}
+
+// testDisruption is a disruption on a line section and a trip
+const testDisruption = `{
+	"id": "disruption-1",
+	"severity": {"name": "no service", "effect": "NO_SERVICE"},
+	"application_periods": [{"begin": "20170429T220000", "end": "20170430T053000"}],
+	"messages": [
+		{"text": "<p>Works</p>", "channel": {"content_type": "text/html", "types": ["web"]}},
+		{"text": "Line 14 interrupted", "channel": {"content_type": "text/plain", "types": ["title"]}},
+		{"text": "Line 14 is interrupted between Gare de Lyon and Olympiades", "channel": {"content_type": "text/plain", "types": ["mobile"]}}
+	],
+	"impacted_objects": [
+		{"pt_object": {"id": "line:RAT:M14", "embedded_type": "line"}},
+		{"pt_object": {"id": "network:RAT:1", "embedded_type": "network"}},
+		{
+			"pt_object": {"id": "vehicle_journey:RAT:1", "embedded_type": "trip"},
+			"impacted_stops": [
+				{"stop_point": {"id": "stop_point:RAT:SP:GDLYO"}, "stop_time_effect": "deleted"},
+				{"stop_point": {"id": "stop_point:RAT:SP:BIBLI"}, "stop_time_effect": "unchanged"}
+			]
+		}
+	]
+}`
+
+// TestNewAlertFeed checks the conversion of a disruption into an alert, and its serializations
+func TestNewAlertFeed(t *testing.T) {
+	var d types.Disruption
+	if err := json.Unmarshal([]byte(testDisruption), &d); err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone unavailable: %v", err)
+	}
+
+	opts := Options{
+		Location:  paris,
+		Language:  "fr",
+		Timestamp: time.Unix(1493500000, 0),
+		ID: func(id types.ID) string {
+			return "gtfs:" + string(id)
+		},
+	}
+	feed := NewAlertFeed([]types.Disruption{d}, opts)
+	if len(feed.Entity) != 1 || feed.Entity[0].Alert == nil {
+		t.Fatalf("expected one alert, got %+v", feed.Entity)
+	}
+
+	a := feed.Entity[0].Alert
+	if a.Effect != NoService || a.SeverityLevel != Severe {
+		t.Errorf("got effect %v & severity %v", a.Effect, a.SeverityLevel)
+	}
+	// 22:00 in Paris, in summer time
+	if len(a.ActivePeriod) != 1 || a.ActivePeriod[0].Start != 1493496000 {
+		t.Errorf("unexpected active periods: %+v", a.ActivePeriod)
+	}
+	if a.HeaderText == nil || a.HeaderText.Translation[0].Text != "Line 14 interrupted" || a.HeaderText.Translation[0].Language != "fr" {
+		t.Errorf("unexpected header text: %+v", a.HeaderText)
+	}
+	if a.DescriptionText == nil || a.DescriptionText.Translation[0].Text != "Line 14 is interrupted between Gare de Lyon and Olympiades" {
+		t.Errorf("unexpected description text: %+v", a.DescriptionText)
+	}
+	if got := len(a.InformedEntity); got != 3 {
+		t.Fatalf("expected 3 informed entities, got %d: %+v", got, a.InformedEntity)
+	}
+	if es := a.InformedEntity[2]; es.Trip == nil || es.Trip.TripID != "gtfs:vehicle_journey:RAT:1" || es.StopID != "gtfs:stop_point:RAT:SP:GDLYO" {
+		t.Errorf("unexpected trip selector: %+v", es)
+	}
+
+	// Protobuf
+	b, err := feed.Marshal()
+	if err != nil {
+		t.Fatalf("error in Marshal: %v", err)
+	}
+	msg := decodeWire(t, b)
+	header := decodeWire(t, field(msg, 1)[0].bytes)
+	if v := field(header, 1); len(v) != 1 || string(v[0].bytes) != Version {
+		t.Errorf("unexpected version in header: %v", v)
+	}
+	if v := field(header, 3); len(v) != 1 || v[0].value != 1493500000 {
+		t.Errorf("unexpected timestamp in header: %v", v)
+	}
+	entity := decodeWire(t, field(msg, 2)[0].bytes)
+	if v := field(entity, 1); string(v[0].bytes) != "disruption-1" {
+		t.Errorf("unexpected entity ID: %q", v[0].bytes)
+	}
+	alert := decodeWire(t, field(entity, 5)[0].bytes)
+	if v := field(alert, 7); len(v) != 1 || v[0].value != uint64(NoService) {
+		t.Errorf("unexpected effect: %v", v)
+	}
+	if v := field(alert, 5); len(v) != 3 {
+		t.Errorf("expected 3 informed entities, got %d", len(v))
+	}
+
+	// JSON
+	j, err := json.Marshal(feed)
+	if err != nil {
+		t.Fatalf("error in json.Marshal: %v", err)
+	}
+	for _, want := range []string{`"gtfsRealtimeVersion":"2.0"`, `"effect":"NO_SERVICE"`, `"severityLevel":"SEVERE"`, `"start":"1493496000"`, `"agencyId":"gtfs:network:RAT:1"`} {
+		if !bytes.Contains(j, []byte(want)) {
+			t.Errorf("expected %s in the JSON feed, got %s", want, j)
+		}
+	}
+}
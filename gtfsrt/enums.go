@@ -0,0 +1,181 @@
+package gtfsrt
+
+// Incrementality tells whether a feed is a full dataset or a differential one
+type Incrementality int32
+
+// The known incrementalities
+const (
+	FullDataset  Incrementality = 0
+	Differential Incrementality = 1
+)
+
+// Cause is the cause of an alert
+type Cause int32
+
+// The known causes
+const (
+	UnknownCause     Cause = 1
+	OtherCause       Cause = 2
+	TechnicalProblem Cause = 3
+	Strike           Cause = 4
+	Demonstration    Cause = 5
+	Accident         Cause = 6
+	Holiday          Cause = 7
+	Weather          Cause = 8
+	Maintenance      Cause = 9
+	Construction     Cause = 10
+	PoliceActivity   Cause = 11
+	MedicalEmergency Cause = 12
+)
+
+// Effect is the effect of an alert
+type Effect int32
+
+// The known effects
+const (
+	NoService          Effect = 1
+	ReducedService     Effect = 2
+	SignificantDelays  Effect = 3
+	Detour             Effect = 4
+	AdditionalService  Effect = 5
+	ModifiedService    Effect = 6
+	OtherEffect        Effect = 7
+	UnknownEffect      Effect = 8
+	StopMoved          Effect = 9
+	NoEffect           Effect = 10
+	AccessibilityIssue Effect = 11
+)
+
+// SeverityLevel is the severity of an alert
+type SeverityLevel int32
+
+// The known severity levels
+const (
+	UnknownSeverity SeverityLevel = 1
+	Info            SeverityLevel = 2
+	Warning         SeverityLevel = 3
+	Severe          SeverityLevel = 4
+)
+
+// TripScheduleRelationship is the relationship between a trip and its schedule
+type TripScheduleRelationship int32
+
+// The known trip schedule relationships
+const (
+	TripScheduled   TripScheduleRelationship = 0
+	TripAdded       TripScheduleRelationship = 1
+	TripUnscheduled TripScheduleRelationship = 2
+	TripCanceled    TripScheduleRelationship = 3
+	TripDuplicated  TripScheduleRelationship = 6
+	TripDeleted     TripScheduleRelationship = 7
+)
+
+// StopTimeScheduleRelationship is the relationship between a stop time and its schedule
+type StopTimeScheduleRelationship int32
+
+// The known stop time schedule relationships
+const (
+	StopTimeScheduled   StopTimeScheduleRelationship = 0
+	StopTimeSkipped     StopTimeScheduleRelationship = 1
+	StopTimeNoData      StopTimeScheduleRelationship = 2
+	StopTimeUnscheduled StopTimeScheduleRelationship = 3
+)
+
+// The protobuf names of the values of each enum
+var (
+	incrementalityNames = map[Incrementality]string{
+		FullDataset:  "FULL_DATASET",
+		Differential: "DIFFERENTIAL",
+	}
+	causeNames = map[Cause]string{
+		UnknownCause:     "UNKNOWN_CAUSE",
+		OtherCause:       "OTHER_CAUSE",
+		TechnicalProblem: "TECHNICAL_PROBLEM",
+		Strike:           "STRIKE",
+		Demonstration:    "DEMONSTRATION",
+		Accident:         "ACCIDENT",
+		Holiday:          "HOLIDAY",
+		Weather:          "WEATHER",
+		Maintenance:      "MAINTENANCE",
+		Construction:     "CONSTRUCTION",
+		PoliceActivity:   "POLICE_ACTIVITY",
+		MedicalEmergency: "MEDICAL_EMERGENCY",
+	}
+	effectNames = map[Effect]string{
+		NoService:          "NO_SERVICE",
+		ReducedService:     "REDUCED_SERVICE",
+		SignificantDelays:  "SIGNIFICANT_DELAYS",
+		Detour:             "DETOUR",
+		AdditionalService:  "ADDITIONAL_SERVICE",
+		ModifiedService:    "MODIFIED_SERVICE",
+		OtherEffect:        "OTHER_EFFECT",
+		UnknownEffect:      "UNKNOWN_EFFECT",
+		StopMoved:          "STOP_MOVED",
+		NoEffect:           "NO_EFFECT",
+		AccessibilityIssue: "ACCESSIBILITY_ISSUE",
+	}
+	severityLevelNames = map[SeverityLevel]string{
+		UnknownSeverity: "UNKNOWN_SEVERITY",
+		Info:            "INFO",
+		Warning:         "WARNING",
+		Severe:          "SEVERE",
+	}
+	tripScheduleRelationshipNames = map[TripScheduleRelationship]string{
+		TripScheduled:   "SCHEDULED",
+		TripAdded:       "ADDED",
+		TripUnscheduled: "UNSCHEDULED",
+		TripCanceled:    "CANCELED",
+		TripDuplicated:  "DUPLICATED",
+		TripDeleted:     "DELETED",
+	}
+	stopTimeScheduleRelationshipNames = map[StopTimeScheduleRelationship]string{
+		StopTimeScheduled:   "SCHEDULED",
+		StopTimeSkipped:     "SKIPPED",
+		StopTimeNoData:      "NO_DATA",
+		StopTimeUnscheduled: "UNSCHEDULED",
+	}
+)
+
+// enumText returns the name of an enum value, or "UNKNOWN" if it has none
+func enumText(name string, ok bool) ([]byte, error) {
+	if !ok {
+		return []byte("UNKNOWN"), nil
+	}
+	return []byte(name), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the protobuf name of the value
+func (i Incrementality) MarshalText() ([]byte, error) {
+	name, ok := incrementalityNames[i]
+	return enumText(name, ok)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the protobuf name of the value
+func (c Cause) MarshalText() ([]byte, error) {
+	name, ok := causeNames[c]
+	return enumText(name, ok)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the protobuf name of the value
+func (e Effect) MarshalText() ([]byte, error) {
+	name, ok := effectNames[e]
+	return enumText(name, ok)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the protobuf name of the value
+func (s SeverityLevel) MarshalText() ([]byte, error) {
+	name, ok := severityLevelNames[s]
+	return enumText(name, ok)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the protobuf name of the value
+func (r TripScheduleRelationship) MarshalText() ([]byte, error) {
+	name, ok := tripScheduleRelationshipNames[r]
+	return enumText(name, ok)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the protobuf name of the value
+func (r StopTimeScheduleRelationship) MarshalText() ([]byte, error) {
+	name, ok := stopTimeScheduleRelationshipNames[r]
+	return enumText(name, ok)
+}
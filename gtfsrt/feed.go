@@ -0,0 +1,168 @@
+// Package gtfsrt exports Navitia data as GTFS-Realtime feeds, for consumers which don't speak Navitia.
+//
+// It models the subset of the GTFS-Realtime specification (https://gtfs.org/realtime/reference/) needed to
+// publish service alerts and trip updates. A FeedMessage is serialized as protobuf through its Marshal method,
+// and as JSON through encoding/json, following the canonical protobuf JSON mapping (lowerCamelCase field names, enums as their names).
+//
+// As GTFS and Navitia identifiers seldom match, every ID goes through Options.ID, which defaults to the Navitia ID.
+package gtfsrt
+
+import (
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// Version is the version of the GTFS-Realtime specification the feeds follow
+const Version = "2.0"
+
+// Options holds the options of the conversion from Navitia objects to GTFS-Realtime entities.
+type Options struct {
+	// Location is the time zone of the wall-clock times given by Navitia, usually that of the region.
+	// If nil, UTC is used.
+	Location *time.Location
+
+	// Language of the texts, such as "fr". It may be left empty.
+	Language string
+
+	// Timestamp of the feed. If zero, the current time is used.
+	Timestamp time.Time
+
+	// ServiceDate is the service day of the vehicle journeys whose disruptions don't tell it.
+	// If zero, the date of the Timestamp is used.
+	ServiceDate time.Time
+
+	// ID maps a Navitia ID to its GTFS counterpart. If nil, the Navitia ID is used as-is.
+	ID func(types.ID) string
+}
+
+// location returns the time zone of Navitia's wall-clock times
+func (opts Options) location() *time.Location {
+	if opts.Location == nil {
+		return time.UTC
+	}
+	return opts.Location
+}
+
+// id maps a Navitia ID to a GTFS ID
+func (opts Options) id(id types.ID) string {
+	if opts.ID == nil {
+		return string(id)
+	}
+	return opts.ID(id)
+}
+
+// posix returns the POSIX time of a Navitia wall-clock time, 0 for the zero time
+func (opts Options) posix(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(types.InLocation(t, opts.location()).Unix())
+}
+
+// timestamp returns the timestamp of the feed
+func (opts Options) timestamp() time.Time {
+	if opts.Timestamp.IsZero() {
+		return time.Now()
+	}
+	return opts.Timestamp
+}
+
+// header returns the header of a full dataset feed
+func (opts Options) header() FeedHeader {
+	return FeedHeader{
+		GtfsRealtimeVersion: Version,
+		Incrementality:      FullDataset,
+		Timestamp:           uint64(opts.timestamp().Unix()),
+	}
+}
+
+// A FeedMessage is the content of a GTFS-Realtime feed
+type FeedMessage struct {
+	Header FeedHeader   `json:"header"`
+	Entity []FeedEntity `json:"entity,omitempty"`
+}
+
+// A FeedHeader holds the metadata of a feed
+type FeedHeader struct {
+	GtfsRealtimeVersion string         `json:"gtfsRealtimeVersion"`
+	Incrementality      Incrementality `json:"incrementality,omitempty"`
+	Timestamp           uint64         `json:"timestamp,string,omitempty"`
+}
+
+// A FeedEntity is an entity of a feed, here either a trip update or an alert
+type FeedEntity struct {
+	ID         string      `json:"id"`
+	IsDeleted  bool        `json:"isDeleted,omitempty"`
+	TripUpdate *TripUpdate `json:"tripUpdate,omitempty"`
+	Alert      *Alert      `json:"alert,omitempty"`
+}
+
+// A TimeRange is a time interval, as POSIX times. A zero bound means the interval is open on that side.
+type TimeRange struct {
+	Start uint64 `json:"start,string,omitempty"`
+	End   uint64 `json:"end,string,omitempty"`
+}
+
+// An EntitySelector selects the objects an alert is about
+type EntitySelector struct {
+	AgencyID string          `json:"agencyId,omitempty"`
+	RouteID  string          `json:"routeId,omitempty"`
+	Trip     *TripDescriptor `json:"trip,omitempty"`
+	StopID   string          `json:"stopId,omitempty"`
+}
+
+// A TranslatedString is a text, in one or more languages
+type TranslatedString struct {
+	Translation []Translation `json:"translation"`
+}
+
+// A Translation is a text in a given language
+type Translation struct {
+	Text     string `json:"text"`
+	Language string `json:"language,omitempty"`
+}
+
+// An Alert is a service alert
+type Alert struct {
+	ActivePeriod    []TimeRange       `json:"activePeriod,omitempty"`
+	InformedEntity  []EntitySelector  `json:"informedEntity,omitempty"`
+	Cause           Cause             `json:"cause,omitempty"`
+	Effect          Effect            `json:"effect,omitempty"`
+	URL             *TranslatedString `json:"url,omitempty"`
+	HeaderText      *TranslatedString `json:"headerText,omitempty"`
+	DescriptionText *TranslatedString `json:"descriptionText,omitempty"`
+	SeverityLevel   SeverityLevel     `json:"severityLevel,omitempty"`
+}
+
+// A TripDescriptor identifies a trip
+type TripDescriptor struct {
+	TripID               string                   `json:"tripId,omitempty"`
+	StartTime            string                   `json:"startTime,omitempty"` // HH:MM:SS
+	StartDate            string                   `json:"startDate,omitempty"` // YYYYMMDD
+	ScheduleRelationship TripScheduleRelationship `json:"scheduleRelationship,omitempty"`
+	RouteID              string                   `json:"routeId,omitempty"`
+}
+
+// A TripUpdate reports the realtime progress of a trip
+type TripUpdate struct {
+	Trip           TripDescriptor   `json:"trip"`
+	StopTimeUpdate []StopTimeUpdate `json:"stopTimeUpdate,omitempty"`
+	Timestamp      uint64           `json:"timestamp,string,omitempty"`
+	Delay          *int32           `json:"delay,omitempty"`
+}
+
+// A StopTimeEvent is the realtime arrival or departure at a stop
+type StopTimeEvent struct {
+	Delay *int32 `json:"delay,omitempty"` // In seconds
+	Time  int64  `json:"time,string,omitempty"`
+}
+
+// A StopTimeUpdate is the realtime update of a stop of a trip
+type StopTimeUpdate struct {
+	StopSequence         *uint32                      `json:"stopSequence,omitempty"`
+	Arrival              *StopTimeEvent               `json:"arrival,omitempty"`
+	Departure            *StopTimeEvent               `json:"departure,omitempty"`
+	StopID               string                       `json:"stopId,omitempty"`
+	ScheduleRelationship StopTimeScheduleRelationship `json:"scheduleRelationship,omitempty"`
+}
@@ -0,0 +1,158 @@
+package gtfsrt
+
+import (
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// NewTripUpdateFeed converts the vehicle journeys into a feed of trip updates, one per vehicle journey impacted by the disruptions.
+//
+// The disruptions are typically those of the vehicle journeys results. The full disruptions held by the vehicle journeys themselves
+// are taken into account too.
+func NewTripUpdateFeed(vjs []types.VehicleJourney, disruptions []types.Disruption, opts Options) *FeedMessage {
+	set := types.NewDisruptionSet(disruptions)
+
+	fm := &FeedMessage{Header: opts.header()}
+	for _, vj := range vjs {
+		impacting := append(set.Affecting(vj.ID), vj.Disruptions...)
+		if vj.Trip.ID != "" && vj.Trip.ID != vj.ID {
+			impacting = append(impacting, set.Affecting(vj.Trip.ID)...)
+		}
+		if tu := NewTripUpdate(vj, impacting, opts); tu != nil {
+			fm.Entity = append(fm.Entity, FeedEntity{
+				ID:         string(vj.ID),
+				TripUpdate: tu,
+			})
+		}
+	}
+	return fm
+}
+
+// NewTripUpdate converts a vehicle journey into a trip update, following the latest of the given disruptions impacting its trip.
+// It returns nil if none does, or if the disruption neither cancels the trip nor impacts any of its stops.
+//
+// A trip without service, with no stop left, is cancelled. Otherwise each impacted stop is updated: deleted stops are skipped,
+// and the others get their amended arrival & departure times and delays.
+// The stops are identified by their stop_id only, as Navitia doesn't give the GTFS stop_sequence.
+// Their service day is the date of the disruption's first application period, or else Options.ServiceDate.
+func NewTripUpdate(vj types.VehicleJourney, disruptions []types.Disruption, opts Options) *TripUpdate {
+	d, impact, ok := latestTripImpact(vj, disruptions)
+	if !ok {
+		return nil
+	}
+	day := opts.serviceDay(d)
+
+	tu := &TripUpdate{
+		Trip: TripDescriptor{
+			TripID:    opts.id(vj.ID),
+			StartDate: day.Format("20060102"),
+		},
+	}
+	tu.Timestamp = opts.posix(d.LastUpdated)
+	if len(vj.StopTimes) != 0 && vj.StopTimes[0].DepartureTime != "" {
+		tu.Trip.StartTime = vj.StopTimes[0].Departure.String()
+	}
+
+	// Is the trip cancelled ?
+	cancelled := d.Severity.Effect == types.EffectNoService
+	for _, is := range impact.ImpactedStops {
+		if is.StopTimeEffect != types.StopTimeDeleted {
+			cancelled = false
+		}
+	}
+	if cancelled {
+		tu.Trip.ScheduleRelationship = TripCanceled
+		return tu
+	}
+
+	// Now the stops, matched in order with the stop times to find their scheduled times
+	next := 0
+	for _, is := range impact.ImpactedStops {
+		stu := StopTimeUpdate{StopID: opts.id(is.Point.ID)}
+		var st types.StopTime
+		for k := next; k < len(vj.StopTimes); k++ {
+			if vj.StopTimes[k].StopPoint.ID == is.Point.ID {
+				st = vj.StopTimes[k]
+				next = k + 1
+				break
+			}
+		}
+
+		if is.StopTimeEffect == types.StopTimeDeleted {
+			stu.ScheduleRelationship = StopTimeSkipped
+		} else {
+			stu.Arrival = stopTimeEvent(is.AmendedArrivalTime, is.AmendedArrival, is.BaseArrivalTime, is.BaseArrival, st.Arrival, day)
+			stu.Departure = stopTimeEvent(is.AmendedDepartureTime, is.AmendedDeparture, is.BaseDepartureTime, is.BaseDeparture, st.Departure, day)
+			if stu.Arrival == nil && stu.Departure == nil {
+				stu.ScheduleRelationship = StopTimeNoData
+			}
+		}
+		tu.StopTimeUpdate = append(tu.StopTimeUpdate, stu)
+	}
+
+	// Nothing to update
+	if len(tu.StopTimeUpdate) == 0 {
+		return nil
+	}
+
+	return tu
+}
+
+// latestTripImpact returns the most recently updated of the disruptions impacting the trip of the vehicle journey, along with that impact
+func latestTripImpact(vj types.VehicleJourney, disruptions []types.Disruption) (types.Disruption, types.ImpactedObject, bool) {
+	var (
+		latest types.Disruption
+		impact types.ImpactedObject
+		found  bool
+	)
+	for _, d := range disruptions {
+		for _, io := range d.Impacted {
+			id := io.Object.ID
+			if id == "" || (id != vj.ID && id != vj.Trip.ID) {
+				continue
+			}
+			if !found || d.LastUpdated.After(latest.LastUpdated) {
+				latest, impact, found = d, io, true
+			}
+		}
+	}
+	return latest, impact, found
+}
+
+// serviceDay returns the service day of a trip impacted by the disruption, at midnight in the options' location
+func (opts Options) serviceDay(d types.Disruption) time.Time {
+	var day time.Time
+	switch {
+	case len(d.Periods) != 0 && !d.Periods[0].Begin.IsZero():
+		day = d.Periods[0].Begin
+	case !opts.ServiceDate.IsZero():
+		day = opts.ServiceDate
+	default:
+		day = opts.timestamp().In(opts.location())
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, opts.location())
+}
+
+// stopTimeEvent returns the event of an amended time of day on the given service day, with its delay if the base time is known.
+// It returns nil if there's no amended time.
+//
+// As the impacted stops give their times modulo 24 hours, the base time is brought back next to the scheduled one,
+// and the amended time next to the base one.
+func stopTimeEvent(amendedRaw string, amended types.ServiceTime, baseRaw string, base types.ServiceTime, scheduled types.ServiceTime, day time.Time) *StopTimeEvent {
+	if amendedRaw == "" {
+		return nil
+	}
+	if baseRaw == "" {
+		amended = amended.Unwrap(scheduled)
+		return &StopTimeEvent{Time: amended.On(day).Unix()}
+	}
+
+	base = base.Unwrap(scheduled)
+	amended = amended.Unwrap(base)
+	delay := int32(time.Duration(amended-base) / time.Second)
+	return &StopTimeEvent{
+		Delay: &delay,
+		Time:  amended.On(day).Unix(),
+	}
+}
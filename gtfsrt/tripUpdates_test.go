@@ -0,0 +1,166 @@
+package gtfsrt
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// testVehicleJourneys are two vehicle journeys, the first one running past midnight
+const testVehicleJourneys = `[
+	{
+		"id": "vehicle_journey:RAT:1",
+		"stop_times": [
+			{"stop_point": {"id": "stop_point:RAT:SP:GDLYO"}, "arrival_time": "235000", "departure_time": "235000"},
+			{"stop_point": {"id": "stop_point:RAT:SP:BERCY"}, "arrival_time": "235800", "departure_time": "235900"},
+			{"stop_point": {"id": "stop_point:RAT:SP:BIBLI"}, "arrival_time": "000500", "departure_time": "000500"}
+		]
+	},
+	{
+		"id": "vehicle_journey:RAT:2",
+		"stop_times": [
+			{"stop_point": {"id": "stop_point:RAT:SP:GDLYO"}, "arrival_time": "080000", "departure_time": "080000"},
+			{"stop_point": {"id": "stop_point:RAT:SP:BIBLI"}, "arrival_time": "081000", "departure_time": "081000"}
+		]
+	},
+	{
+		"id": "vehicle_journey:RAT:3",
+		"stop_times": [
+			{"stop_point": {"id": "stop_point:RAT:SP:GDLYO"}, "arrival_time": "090000", "departure_time": "090000"}
+		]
+	}
+]`
+
+// testTripDisruptions delay the first vehicle journey, skipping its first stop, cancel the second one,
+// and impact the third one without telling how
+const testTripDisruptions = `[
+	{
+		"id": "disruption-delay",
+		"updated_at": "20170429T220500",
+		"severity": {"name": "delayed", "effect": "SIGNIFICANT_DELAYS"},
+		"application_periods": [{"begin": "20170429T234000", "end": "20170430T010000"}],
+		"impacted_objects": [{
+			"pt_object": {"id": "vehicle_journey:RAT:1", "embedded_type": "trip"},
+			"impacted_stops": [
+				{"stop_point": {"id": "stop_point:RAT:SP:GDLYO"}, "stop_time_effect": "deleted", "base_departure_time": "235000"},
+				{"stop_point": {"id": "stop_point:RAT:SP:BERCY"}, "stop_time_effect": "delayed",
+					"base_arrival_time": "235800", "amended_arrival_time": "000300", "base_departure_time": "235900", "amended_departure_time": "000400"},
+				{"stop_point": {"id": "stop_point:RAT:SP:BIBLI"}, "stop_time_effect": "delayed",
+					"base_arrival_time": "000500", "amended_arrival_time": "001000"}
+			]
+		}]
+	},
+	{
+		"id": "disruption-cancel",
+		"severity": {"name": "no service", "effect": "NO_SERVICE"},
+		"impacted_objects": [{"pt_object": {"id": "vehicle_journey:RAT:2", "embedded_type": "trip"}}]
+	},
+	{
+		"id": "disruption-vague",
+		"severity": {"name": "other", "effect": "OTHER_EFFECT"},
+		"impacted_objects": [{"pt_object": {"id": "vehicle_journey:RAT:3", "embedded_type": "trip"}}]
+	}
+]`
+
+// TestNewTripUpdateFeed checks the conversion of disrupted vehicle journeys into trip updates, and their serializations
+func TestNewTripUpdateFeed(t *testing.T) {
+	var (
+		vjs []types.VehicleJourney
+		ds  []types.Disruption
+	)
+	if err := json.Unmarshal([]byte(testVehicleJourneys), &vjs); err != nil {
+		t.Fatalf("unexpected error while unmarshalling vehicle journeys: %v", err)
+	}
+	if err := json.Unmarshal([]byte(testTripDisruptions), &ds); err != nil {
+		t.Fatalf("unexpected error while unmarshalling disruptions: %v", err)
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone unavailable: %v", err)
+	}
+
+	opts := Options{
+		Location:    paris,
+		Timestamp:   time.Unix(1493500000, 0),
+		ServiceDate: time.Date(2017, time.May, 2, 0, 0, 0, 0, time.UTC),
+	}
+	feed := NewTripUpdateFeed(vjs, ds, opts)
+	// The third vehicle journey has nothing to update
+	if len(feed.Entity) != 2 {
+		t.Fatalf("expected two trip updates, got %+v", feed.Entity)
+	}
+
+	// The delayed trip
+	delayed := feed.Entity[0].TripUpdate
+	if delayed == nil || feed.Entity[0].ID != "vehicle_journey:RAT:1" {
+		t.Fatalf("unexpected first entity: %+v", feed.Entity[0])
+	}
+	if tr := delayed.Trip; tr.TripID != "vehicle_journey:RAT:1" || tr.StartDate != "20170429" || tr.StartTime != "23:50:00" || tr.ScheduleRelationship != TripScheduled {
+		t.Errorf("unexpected trip descriptor: %+v", tr)
+	}
+	// Updated at 22:05 in Paris, in summer time
+	if want := uint64(time.Date(2017, time.April, 29, 22, 5, 0, 0, paris).Unix()); delayed.Timestamp != want {
+		t.Errorf("expected timestamp %d, got %d", want, delayed.Timestamp)
+	}
+	if len(delayed.StopTimeUpdate) != 3 {
+		t.Fatalf("expected 3 stop time updates, got %+v", delayed.StopTimeUpdate)
+	}
+	for _, stu := range delayed.StopTimeUpdate {
+		if stu.StopSequence != nil {
+			t.Errorf("expected no stop sequence, as Navitia doesn't give it, got %d for %s", *stu.StopSequence, stu.StopID)
+		}
+	}
+	if stu := delayed.StopTimeUpdate[0]; stu.ScheduleRelationship != StopTimeSkipped || stu.StopID != "stop_point:RAT:SP:GDLYO" {
+		t.Errorf("expected the first stop to be skipped, got %+v", stu)
+	}
+	stu := delayed.StopTimeUpdate[1]
+	if stu.StopID != "stop_point:RAT:SP:BERCY" || stu.Arrival == nil || stu.Departure == nil {
+		t.Fatalf("unexpected second stop time update: %+v", stu)
+	}
+	if *stu.Arrival.Delay != 300 || *stu.Departure.Delay != 300 {
+		t.Errorf("expected 5 minutes of delay, got %d & %d", *stu.Arrival.Delay, *stu.Departure.Delay)
+	}
+	// 00:03 in Paris on the 30th, in summer time
+	if want := time.Date(2017, time.April, 30, 0, 3, 0, 0, paris).Unix(); stu.Arrival.Time != want {
+		t.Errorf("expected arrival at %d, got %d", want, stu.Arrival.Time)
+	}
+	if stu := delayed.StopTimeUpdate[2]; stu.Arrival == nil || *stu.Arrival.Delay != 300 || stu.Departure != nil {
+		t.Errorf("unexpected last stop time update: %+v", stu)
+	}
+
+	// The cancelled trip, on the service date given
+	cancelled := feed.Entity[1].TripUpdate
+	if cancelled == nil || cancelled.Trip.ScheduleRelationship != TripCanceled || cancelled.Trip.StartDate != "20170502" || len(cancelled.StopTimeUpdate) != 0 {
+		t.Errorf("expected a cancelled trip, got %+v", cancelled)
+	}
+
+	// Protobuf
+	b, err := feed.Marshal()
+	if err != nil {
+		t.Fatalf("error in Marshal: %v", err)
+	}
+	msg := decodeWire(t, b)
+	entity := decodeWire(t, field(msg, 2)[0].bytes)
+	tu := decodeWire(t, field(entity, 3)[0].bytes)
+	if v := field(tu, 2); len(v) != 3 {
+		t.Errorf("expected 3 stop time updates, got %d", len(v))
+	}
+	skipped := decodeWire(t, field(tu, 2)[0].bytes)
+	if v := field(skipped, 5); len(v) != 1 || v[0].value != uint64(StopTimeSkipped) {
+		t.Errorf("unexpected schedule relationship: %v", v)
+	}
+
+	// JSON
+	j, err := json.Marshal(feed)
+	if err != nil {
+		t.Fatalf("error in json.Marshal: %v", err)
+	}
+	for _, want := range []string{`"scheduleRelationship":"SKIPPED"`, `"scheduleRelationship":"CANCELED"`, `"delay":300`, `"startDate":"20170429"`} {
+		if !bytes.Contains(j, []byte(want)) {
+			t.Errorf("expected %s in the JSON feed, got %s", want, j)
+		}
+	}
+}
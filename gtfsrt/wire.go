@@ -0,0 +1,197 @@
+package gtfsrt
+
+// This file implements the protobuf wire format for the messages of the package, sparing us a dependency on a protobuf runtime.
+// See https://protobuf.dev/programming-guides/encoding/
+
+// Wire types
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+// An encoder appends protobuf fields to a buffer
+type encoder struct {
+	buf []byte
+}
+
+// varint appends a base 128 varint
+func (e *encoder) varint(v uint64) {
+	for v >= 0x80 {
+		e.buf = append(e.buf, byte(v)|0x80)
+		v >>= 7
+	}
+	e.buf = append(e.buf, byte(v))
+}
+
+// key appends the key of a field
+func (e *encoder) key(field int, wireType int) {
+	e.varint(uint64(field)<<3 | uint64(wireType))
+}
+
+// uint appends a varint field, omitting it if zero
+func (e *encoder) uint(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+	e.key(field, wireVarint)
+	e.varint(v)
+}
+
+// int appends a signed (int32 or int64) varint field, omitting it if zero.
+// Negative values are sign-extended to 64 bits, as mandated for int32 & int64.
+func (e *encoder) int(field int, v int64) {
+	e.uint(field, uint64(v))
+}
+
+// optionalInt appends a signed varint field if it is present, even if zero
+func (e *encoder) optionalInt(field int, v *int32) {
+	if v == nil {
+		return
+	}
+	e.key(field, wireVarint)
+	e.varint(uint64(int64(*v)))
+}
+
+// bool appends a boolean field, omitting it if false
+func (e *encoder) bool(field int, v bool) {
+	if v {
+		e.uint(field, 1)
+	}
+}
+
+// string appends a string field, omitting it if empty
+func (e *encoder) string(field int, s string) {
+	if s == "" {
+		return
+	}
+	e.requiredString(field, s)
+}
+
+// requiredString appends a string field, even if empty
+func (e *encoder) requiredString(field int, s string) {
+	e.key(field, wireBytes)
+	e.varint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+// message appends an embedded message field, encoded by the given function
+func (e *encoder) message(field int, encode func(*encoder)) {
+	var sub encoder
+	encode(&sub)
+	e.key(field, wireBytes)
+	e.varint(uint64(len(sub.buf)))
+	e.buf = append(e.buf, sub.buf...)
+}
+
+// Marshal returns the feed serialized in the protobuf wire format
+func (fm *FeedMessage) Marshal() ([]byte, error) {
+	var e encoder
+	fm.encode(&e)
+	return e.buf, nil
+}
+
+func (fm *FeedMessage) encode(e *encoder) {
+	e.message(1, fm.Header.encode)
+	for i := range fm.Entity {
+		e.message(2, fm.Entity[i].encode)
+	}
+}
+
+func (h *FeedHeader) encode(e *encoder) {
+	e.requiredString(1, h.GtfsRealtimeVersion)
+	e.uint(2, uint64(h.Incrementality))
+	e.uint(3, h.Timestamp)
+}
+
+func (fe *FeedEntity) encode(e *encoder) {
+	e.requiredString(1, fe.ID)
+	e.bool(2, fe.IsDeleted)
+	if fe.TripUpdate != nil {
+		e.message(3, fe.TripUpdate.encode)
+	}
+	if fe.Alert != nil {
+		e.message(5, fe.Alert.encode)
+	}
+}
+
+func (tr *TimeRange) encode(e *encoder) {
+	e.uint(1, tr.Start)
+	e.uint(2, tr.End)
+}
+
+func (es *EntitySelector) encode(e *encoder) {
+	e.string(1, es.AgencyID)
+	e.string(2, es.RouteID)
+	if es.Trip != nil {
+		e.message(4, es.Trip.encode)
+	}
+	e.string(5, es.StopID)
+}
+
+func (ts *TranslatedString) encode(e *encoder) {
+	for i := range ts.Translation {
+		t := &ts.Translation[i]
+		e.message(1, func(e *encoder) {
+			e.requiredString(1, t.Text)
+			e.string(2, t.Language)
+		})
+	}
+}
+
+func (a *Alert) encode(e *encoder) {
+	for i := range a.ActivePeriod {
+		e.message(1, a.ActivePeriod[i].encode)
+	}
+	for i := range a.InformedEntity {
+		e.message(5, a.InformedEntity[i].encode)
+	}
+	e.uint(6, uint64(a.Cause))
+	e.uint(7, uint64(a.Effect))
+	if a.URL != nil {
+		e.message(8, a.URL.encode)
+	}
+	if a.HeaderText != nil {
+		e.message(10, a.HeaderText.encode)
+	}
+	if a.DescriptionText != nil {
+		e.message(11, a.DescriptionText.encode)
+	}
+	e.uint(14, uint64(a.SeverityLevel))
+}
+
+func (td *TripDescriptor) encode(e *encoder) {
+	e.string(1, td.TripID)
+	e.string(2, td.StartTime)
+	e.string(3, td.StartDate)
+	e.uint(4, uint64(td.ScheduleRelationship))
+	e.string(5, td.RouteID)
+}
+
+func (tu *TripUpdate) encode(e *encoder) {
+	e.message(1, tu.Trip.encode)
+	for i := range tu.StopTimeUpdate {
+		e.message(2, tu.StopTimeUpdate[i].encode)
+	}
+	e.uint(4, tu.Timestamp)
+	e.optionalInt(5, tu.Delay)
+}
+
+func (ste *StopTimeEvent) encode(e *encoder) {
+	e.optionalInt(1, ste.Delay)
+	e.int(2, ste.Time)
+}
+
+func (stu *StopTimeUpdate) encode(e *encoder) {
+	if stu.StopSequence != nil {
+		e.key(1, wireVarint)
+		e.varint(uint64(*stu.StopSequence))
+	}
+	if stu.Arrival != nil {
+		e.message(2, stu.Arrival.encode)
+	}
+	if stu.Departure != nil {
+		e.message(3, stu.Departure.encode)
+	}
+	e.string(4, stu.StopID)
+	e.uint(5, uint64(stu.ScheduleRelationship))
+}
@@ -3,6 +3,8 @@ package navitia
 import (
 	"flag"
 	"net/http"
+	"os"
+	"testing"
 )
 
 const skipNoKey = "No api key supplied, skipping (provide one using -key flag)"
@@ -12,11 +14,21 @@ var (
 	testSession *Session
 )
 
-// Initialise testing function
-func init() {
+// TestMain parses the flags, sets up the testing session & loads the test data before running the tests.
+//
+// This can't be done in an init function, as the testing flags aren't registered yet at that point.
+func TestMain(m *testing.M) {
 	// Populate flags
 	flag.Parse()
 
+	setupSession()
+	setupTestData()
+
+	os.Exit(m.Run())
+}
+
+// setupSession creates the testing session if an API key was provided
+func setupSession() {
 	// Create session
 	if *apiKey != "" {
 		var err error
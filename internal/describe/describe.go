@@ -0,0 +1,67 @@
+// Package describe names journeys & dates Navitia's wall-clock times for the exporters & renderers,
+// so that GPX & KML documents and reports read the same.
+package describe
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// Journey names a journey after its ends, such as "10 Rue de Bercy → Olympiades"
+func Journey(j types.Journey) string {
+	if len(j.Sections) == 0 {
+		return ""
+	}
+	return Ends(j.Sections[0].From.Name, j.Sections[len(j.Sections)-1].To.Name)
+}
+
+// Ends describes a move from a place to another, such as "Bercy → Olympiades"
+func Ends(from, to string) string {
+	return fmt.Sprintf("%s → %s", from, to)
+}
+
+// Section names a section after its line for public transport, such as "Metro 14 to Olympiades", or after its mode
+func Section(s types.Section) string {
+	if s.Type != types.SectionPublicTransport {
+		return Mode(s)
+	}
+
+	// The commercial mode is given by name, such as "Metro"
+	name := strings.TrimSpace(string(s.Display.CommercialMode) + " " + s.Display.Code)
+	if s.Display.Direction != "" {
+		name += " to " + s.Display.Direction
+	}
+	return name
+}
+
+// Mode returns the mode of the section if any, or else its type
+func Mode(s types.Section) string {
+	if s.Mode != "" {
+		return s.Mode
+	}
+	return string(s.Type)
+}
+
+// Path describes the streets to follow, such as "Rue de Bercy (120 m), Quai de la Rapée (300 m)",
+// skipping the unnamed ones
+func Path(path []types.PathSegment) string {
+	var parts []string
+	for _, ps := range path {
+		if ps.Name != "" {
+			parts = append(parts, fmt.Sprintf("%s (%d m)", ps.Name, ps.Length))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Time returns the actual instant of a Navitia wall-clock time in the given location, nil meaning UTC, see types.InLocation.
+// Times already localized, such as those of stop times whose time zone is known, are left unchanged.
+func Time(t time.Time, loc *time.Location) time.Time {
+	if t.Location() != time.UTC {
+		return t
+	}
+	return types.InLocation(t, loc)
+}
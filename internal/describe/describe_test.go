@@ -0,0 +1,35 @@
+package describe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// TestPath checks that unnamed segments are skipped
+func TestPath(t *testing.T) {
+	path := []types.PathSegment{{Name: "Rue de Bercy", Length: 120}, {Length: 30}, {Name: "Quai de la Rapée", Length: 300}}
+	if got, want := Path(path), "Rue de Bercy (120 m), Quai de la Rapée (300 m)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestTime checks that wall-clock times are given their location, and that localized ones are left unchanged
+func TestTime(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	wall := time.Date(2017, 4, 29, 8, 7, 0, 0, time.UTC)
+
+	if got := Time(wall, paris); !got.Equal(time.Date(2017, 4, 29, 6, 7, 0, 0, time.UTC)) {
+		t.Errorf("wall-clock time: got %v", got)
+	}
+	if got := Time(wall, nil); !got.Equal(wall) {
+		t.Errorf("without location: got %v", got)
+	}
+	if local := wall.In(paris); !Time(local, time.UTC).Equal(local) {
+		t.Errorf("localized time: got %v", Time(local, time.UTC))
+	}
+}
@@ -1,9 +1,13 @@
 package navitia
 
 import (
+	"encoding/json"
 	"net/url"
 	"time"
 
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+
 	"github.com/govitia/navitia/types"
 	"github.com/govitia/navitia/utils"
 )
@@ -14,10 +18,56 @@ const journeysEndpoint = "journeys"
 // Warning: types.Journey.From / types.Journey.To aren't guaranteed to be filled.
 // Based on very basic inspection, it seems they aren't filled when there are sections...
 type JourneyResults struct {
-	Journeys []types.Journey `json:"journeys"`
-	Paging   Paging          `json:"links"`
-	Logging  `json:"-"`
-	session  *Session
+	Journeys    []types.Journey    `json:"journeys"`
+	Disruptions []types.Disruption `json:"disruptions"`
+	Paging      Paging             `json:"links"`
+	Context     types.Context      `json:"context"`
+	Logging     `json:"-"`
+	session     *Session
+}
+
+// UnmarshalJSON implements unmarshalling for JourneyResults.
+// The disruptions are resolved onto the sections they impact, and the stop times of the sections whose stop point
+// has no known time zone are located in the region's time zone.
+func (jr *JourneyResults) UnmarshalJSON(b []byte) error {
+	data := &struct {
+		Journeys    *[]types.Journey    `json:"journeys"`
+		Disruptions *[]types.Disruption `json:"disruptions"`
+		Paging      *Paging             `json:"links"`
+		Context     *types.Context      `json:"context"`
+	}{
+		Journeys:    &jr.Journeys,
+		Disruptions: &jr.Disruptions,
+		Paging:      &jr.Paging,
+		Context:     &jr.Context,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "JourneyResults.UnmarshalJSON: error while unmarshalling JourneyResults")
+	}
+
+	// Resolve the disruptions
+	for i := range jr.Journeys {
+		jr.Journeys[i].ResolveDisruptions(jr.Disruptions)
+	}
+
+	loc := jr.Context.Location()
+	if loc == nil {
+		return nil
+	}
+	for _, j := range jr.Journeys {
+		for _, s := range j.Sections {
+			for k := range s.StopTimes {
+				if s.StopTimes[k].StopPoint.TimeLocation() == nil {
+					s.StopTimes[k].Localize(loc)
+				}
+			}
+		}
+	}
+
+	return nil
 }
 
 // Count returns the number of results available in a JourneyResults
@@ -57,17 +107,17 @@ type JourneyRequest struct {
 	LastSectionModes []string
 
 	// MaxDurationToPT is the maximum allowed duration to reach the public transport.
-	// Use this to limit the walking/biking part.
+	// Use this to limit the walking/biking part. Not sent if 0.
 	MaxDurationToPT time.Duration
 
 	// These four following parameters set the speed of each mode (Walking, Bike, BSS & car)
-	// In meters per second
+	// In meters per second, not sent if 0
 	WalkingSpeed   float64
 	BikeSpeed      float64
 	BikeShareSpeed float64
 	CarSpeed       float64
 
-	// Minimum and maximum amounts of journeys suggested
+	// Minimum and maximum amounts of journeys suggested, not sent if 0
 	MinJourneys uint
 	MaxJourneys uint
 
@@ -75,10 +125,10 @@ type JourneyRequest struct {
 	// Note: if Count=0 then it isn't taken into account
 	Count uint
 
-	// Maximum number of transfers in each journey
+	// Maximum number of transfers in each journey, not sent if 0
 	MaxTransfers uint
 
-	// Maximum duration of a trip
+	// Maximum duration of a trip, not sent if 0
 	MaxDuration time.Duration // To seconds
 
 	// Wheelchair restricts the answer to accessible public transports
@@ -87,6 +137,9 @@ type JourneyRequest struct {
 	// Headsign If given, add a filter on the vehicle journeys that has the
 	// given value as headsign (on vehicle journey itself or at a stop time).
 	Headsign string
+
+	// Language of the texts of the reply, such as the names of the places, if the instance supports it
+	Language language.Tag
 }
 
 // toURL formats a journey request to url
@@ -113,27 +166,44 @@ func (req JourneyRequest) toURL() (url.Values, error) {
 	rb.AddMode("last_section_mode[]", req.LastSectionModes)
 
 	// max_duration_to_pt
-	rb.AddInt("max_duration_to_pt", int(req.MaxDurationToPT/time.Second))
+	if req.MaxDurationToPT != 0 {
+		rb.AddInt("max_duration_to_pt", int(req.MaxDurationToPT/time.Second))
+	}
 
 	// walking_speed, bike_speed, bss_speed & car_speed
-	rb.AddFloat64("walking_speed", req.WalkingSpeed)
-	rb.AddFloat64("bike_speed", req.BikeSpeed)
-	rb.AddFloat64("bss_speed", req.BikeShareSpeed)
-	rb.AddFloat64("car_speed", req.CarSpeed)
+	speeds := map[string]float64{
+		"walking_speed": req.WalkingSpeed,
+		"bike_speed":    req.BikeSpeed,
+		"bss_speed":     req.BikeShareSpeed,
+		"car_speed":     req.CarSpeed,
+	}
+	for key, speed := range speeds {
+		if speed != 0 {
+			rb.AddFloat64(key, speed)
+		}
+	}
 
 	// If count is defined don't bother with the minimimal and maximum amount of items to return
 	if req.Count != 0 {
 		rb.AddUInt("count", req.Count)
 	} else {
-		rb.AddUInt("min_nb_journeys", req.MinJourneys)
-		rb.AddUInt("max_nb_journeys", req.MaxJourneys)
+		if req.MinJourneys != 0 {
+			rb.AddUInt("min_nb_journeys", req.MinJourneys)
+		}
+		if req.MaxJourneys != 0 {
+			rb.AddUInt("max_nb_journeys", req.MaxJourneys)
+		}
 	}
 
 	// max_nb_transfers
-	rb.AddUInt("max_nb_transfers", req.MaxTransfers)
+	if req.MaxTransfers != 0 {
+		rb.AddUInt("max_nb_transfers", req.MaxTransfers)
+	}
 
 	// max_duration
-	rb.AddInt("max_duration", int(req.MaxDuration/time.Second))
+	if req.MaxDuration != 0 {
+		rb.AddInt("max_duration", int(req.MaxDuration/time.Second))
+	}
 
 	// headsign
 	rb.AddString("headsign", req.Headsign)
@@ -143,5 +213,7 @@ func (req JourneyRequest) toURL() (url.Values, error) {
 		rb.AddString("wheelchair", "true")
 	}
 
+	rb.AddLanguage("language", req.Language)
+
 	return rb.Values(), nil
 }
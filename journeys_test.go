@@ -2,9 +2,12 @@ package navitia
 
 import (
 	"context"
+	"encoding/json"
 	"reflect"
 	"testing"
 
+	"golang.org/x/text/language"
+
 	"github.com/govitia/navitia/types"
 )
 
@@ -21,6 +24,19 @@ func Test_JourneyRequest_toUrl(t *testing.T) {
 	}
 }
 
+// Test_JourneyRequest_toUrl_Language checks that the language is only given when specified
+func Test_JourneyRequest_toUrl_Language(t *testing.T) {
+	t.Parallel()
+
+	req, err := JourneyRequest{Language: language.MustParse("fr-FR")}.toURL()
+	if err != nil {
+		t.Fatalf("error in JourneyRequest.ToURL: %v", err)
+	}
+	if got := req.Get("language"); got != "fr-FR" {
+		t.Errorf("parameter language: got %q, want %q", got, "fr-FR")
+	}
+}
+
 func Test_Journeys(t *testing.T) {
 	if *apiKey == "" {
 		t.Skip(skipNoKey)
@@ -75,3 +91,30 @@ func Test_Journeys_Paging(t *testing.T) {
 func Test_JourneysResults_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["journeys"], reflect.TypeOf(JourneyResults{}))
 }
+
+// Test_JourneyResults_Disruptions checks that the disruptions of the results are resolved onto the sections they impact
+func Test_JourneyResults_Disruptions(t *testing.T) {
+	raw := []byte(`{
+		"journeys": [{"sections": [
+			{"type": "street_network", "departure_date_time": "20160608T215000", "arrival_date_time": "20160608T220000"},
+			{"type": "public_transport", "departure_date_time": "20160608T220000", "arrival_date_time": "20160608T221500", "links": [{"id": "line:RAT:M14", "type": "line"}]}
+		]}],
+		"disruptions": [{"id": "strike", "impacted_objects": [{"pt_object": {"id": "line:RAT:M14", "embedded_type": "line"}}]}]
+	}`)
+
+	var res JourneyResults
+	if err := json.Unmarshal(raw, &res); err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+	if len(res.Disruptions) != 1 {
+		t.Fatalf("expected 1 disruption in the results, got %d", len(res.Disruptions))
+	}
+
+	sections := res.Journeys[0].Sections
+	if len(sections[0].Disruptions) != 0 {
+		t.Errorf("expected no disruption on the street network section, got %d", len(sections[0].Disruptions))
+	}
+	if len(sections[1].Disruptions) != 1 || sections[1].Disruptions[0].ID != "strike" {
+		t.Errorf("expected the strike on the public transport section, got %v", sections[1].Disruptions)
+	}
+}
@@ -0,0 +1,194 @@
+// Package kml exports journeys as KML 2.2 documents, for use in GPS tools & virtual globes.
+//
+// Each section of a journey is a placemark styled with the color of its line, and its stop points are placemarks too.
+// Walking sections get a placemark at their start describing the streets to follow.
+// Times are written with the offset of their time zone.
+//
+// See https://developers.google.com/kml/documentation/kmlreference
+package kml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/twpayne/go-geom"
+
+	"github.com/govitia/navitia/internal/describe"
+	"github.com/govitia/navitia/shape"
+	"github.com/govitia/navitia/types"
+)
+
+// Namespace of KML 2.2 documents
+const Namespace = "http://www.opengis.net/kml/2.2"
+
+// DefaultColor is the color of the sections without a line color, such as walking ones
+var DefaultColor color.Color = color.NRGBA{R: 0x55, G: 0x55, B: 0x55, A: 0xff}
+
+// LineWidth is the width of the sections' lines, in pixels
+var LineWidth = 4.0
+
+// A KML is a KML 2.2 document
+type KML struct {
+	XMLName   xml.Name `xml:"kml"`
+	Namespace string   `xml:"xmlns,attr"`
+	Document  Document `xml:"Document"`
+}
+
+// A Document holds the styles & placemarks of a KML
+type Document struct {
+	Name       string      `xml:"name,omitempty"`
+	Styles     []Style     `xml:"Style"`
+	Placemarks []Placemark `xml:"Placemark"`
+}
+
+// A Style is a shared style, referenced by placemarks through their StyleURL
+type Style struct {
+	ID        string     `xml:"id,attr"`
+	LineStyle *LineStyle `xml:"LineStyle,omitempty"`
+}
+
+// A LineStyle styles the lines of placemarks
+type LineStyle struct {
+	Color string  `xml:"color"` // Formatted as aabbggrr
+	Width float64 `xml:"width"`
+}
+
+// A Placemark is a feature with a geometry, either a Point or a LineString
+type Placemark struct {
+	Name        string      `xml:"name,omitempty"`
+	Description string      `xml:"description,omitempty"`
+	TimeStamp   *TimeStamp  `xml:"TimeStamp,omitempty"`
+	StyleURL    string      `xml:"styleUrl,omitempty"`
+	Point       *Point      `xml:"Point,omitempty"`
+	LineString  *LineString `xml:"LineString,omitempty"`
+}
+
+// A TimeStamp is a moment in time
+type TimeStamp struct {
+	When string `xml:"when"`
+}
+
+// A Point is a geographic location
+type Point struct {
+	Coordinates string `xml:"coordinates"` // Formatted as "lon,lat"
+}
+
+// A LineString is a connected set of line segments
+type LineString struct {
+	Tessellate  bool   `xml:"tessellate,omitempty"`
+	Coordinates string `xml:"coordinates"` // Formatted as "lon,lat lon,lat ..."
+}
+
+// Journey exports the journey as a KML document, with a placemark per section having a line (see shape.Section),
+// styled with the color of its line, and a placemark for each stop point served.
+//
+// Navitia's times being wall-clock times, loc is their time zone, usually that of the region. If nil, UTC is used.
+func Journey(j types.Journey, loc *time.Location) *KML {
+	k := &KML{Namespace: Namespace}
+	k.Document.Name = describe.Journey(j)
+
+	styles := make(map[string]bool)
+	for _, s := range j.Sections {
+		if ls := shape.Section(s); ls != nil {
+			clr := DefaultColor
+			if s.Type == types.SectionPublicTransport && s.Display.Color != nil {
+				clr = s.Display.Color
+			}
+			style := styleID(clr)
+			if !styles[style] {
+				styles[style] = true
+				k.Document.Styles = append(k.Document.Styles, Style{
+					ID:        style,
+					LineStyle: &LineStyle{Color: kmlColor(clr), Width: LineWidth},
+				})
+			}
+
+			k.Document.Placemarks = append(k.Document.Placemarks, Placemark{
+				Name:        describe.Section(s),
+				Description: describe.Ends(s.From.Name, s.To.Name),
+				StyleURL:    "#" + style,
+				LineString:  &LineString{Tessellate: true, Coordinates: lineCoordinates(ls)},
+			})
+		}
+
+		k.Document.Placemarks = append(k.Document.Placemarks, points(s, loc)...)
+	}
+	return k
+}
+
+// Encode writes the document as XML, with its header
+func (k *KML) Encode(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(k)
+}
+
+// points returns the point placemarks of the section: its stop points with their times,
+// or for a street network section, its start with the streets to follow
+func points(s types.Section, loc *time.Location) []Placemark {
+	var pms []Placemark
+	for _, st := range s.StopTimes {
+		c := st.StopPoint.Coord
+		if c == (types.Coordinates{}) {
+			continue
+		}
+		pm := Placemark{
+			Name:  st.StopPoint.Name,
+			Point: &Point{Coordinates: pointCoordinates(c.Longitude, c.Latitude)},
+		}
+		t := st.PTDateTime.Departure
+		if t.IsZero() {
+			t = st.PTDateTime.Arrival
+		}
+		if !t.IsZero() {
+			pm.TimeStamp = &TimeStamp{When: describe.Time(t, loc).Format(time.RFC3339)}
+		}
+		pms = append(pms, pm)
+	}
+
+	if s.Type == types.SectionStreetNetwork && len(s.Path) != 0 {
+		if c, ok := s.From.Coord(); ok {
+			pms = append(pms, Placemark{
+				Name:        s.From.Name,
+				Description: describe.Path(s.Path),
+				Point:       &Point{Coordinates: pointCoordinates(c.Longitude, c.Latitude)},
+			})
+		}
+	}
+	return pms
+}
+
+// styleID returns the ID of the style of lines of the given color
+func styleID(c color.Color) string {
+	return "line-" + kmlColor(c)
+}
+
+// kmlColor formats a color as KML does, "aabbggrr"
+func kmlColor(c color.Color) string {
+	_, _, _, a := c.RGBA()
+	rgb := types.HexColor(c)
+	return fmt.Sprintf("%02x", a>>8) + rgb[5:7] + rgb[3:5] + rgb[1:3]
+}
+
+// lineCoordinates formats the coordinates of a line
+func lineCoordinates(ls *geom.LineString) string {
+	coords := make([]string, ls.NumCoords())
+	for i := range coords {
+		c := ls.Coord(i)
+		coords[i] = pointCoordinates(c.X(), c.Y())
+	}
+	return strings.Join(coords, " ")
+}
+
+// pointCoordinates formats the coordinates of a point
+func pointCoordinates(lon, lat float64) string {
+	return strconv.FormatFloat(lon, 'f', -1, 64) + "," + strconv.FormatFloat(lat, 'f', -1, 64)
+}
@@ -0,0 +1,93 @@
+package kml
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// testJourney rides the metro 1, then the metro 4 after a transfer at Châtelet
+const testJourney = `{
+	"departure_date_time": "20170629T091000",
+	"sections": [
+		{
+			"id": "section_0", "type": "public_transport", "duration": 300,
+			"display_informations": {"code": "1", "commercial_mode": "Metro", "direction": "La Défense", "color": "FFCE00"},
+			"from": {"id": "stop_point:1", "name": "Bastille", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:1", "coord": {"lon": "2.3690", "lat": "48.8530"}}},
+			"to": {"id": "stop_point:2", "name": "Châtelet", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:2", "coord": {"lon": "2.3470", "lat": "48.8580"}}},
+			"stop_date_times": [
+				{"stop_point": {"id": "stop_point:1", "name": "Bastille", "coord": {"lon": "2.3690", "lat": "48.8530"}}, "departure_date_time": "20170629T091000", "arrival_date_time": "20170629T091000"},
+				{"stop_point": {"id": "stop_point:2", "name": "Châtelet", "coord": {"lon": "2.3470", "lat": "48.8580"}}, "departure_date_time": "20170629T091500", "arrival_date_time": "20170629T091500"}
+			]
+		},
+		{
+			"id": "section_1", "type": "transfer", "transfer_type": "walking", "duration": 180,
+			"from": {"id": "stop_point:2", "name": "Châtelet", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:2", "coord": {"lon": "2.3470", "lat": "48.8580"}}},
+			"to": {"id": "stop_point:3", "name": "Châtelet", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:3", "coord": {"lon": "2.3480", "lat": "48.8590"}}}
+		},
+		{
+			"id": "section_2", "type": "public_transport", "duration": 120,
+			"display_informations": {"code": "4", "commercial_mode": "Metro", "direction": "Mairie de Montrouge", "color": "BE418D"},
+			"from": {"id": "stop_point:3", "name": "Châtelet", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:3", "coord": {"lon": "2.3480", "lat": "48.8590"}}},
+			"to": {"id": "stop_point:4", "name": "Cité", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:4", "coord": {"lon": "2.3470", "lat": "48.8550"}}},
+			"stop_date_times": [
+				{"stop_point": {"id": "stop_point:3", "name": "Châtelet", "coord": {"lon": "2.3480", "lat": "48.8590"}}, "departure_date_time": "20170629T091900", "arrival_date_time": "20170629T091900"},
+				{"stop_point": {"id": "stop_point:4", "name": "Cité", "coord": {"lon": "2.3470", "lat": "48.8550"}}, "departure_date_time": "20170629T092100", "arrival_date_time": "20170629T092100"}
+			]
+		}
+	]
+}`
+
+// TestJourney checks the export of a journey, with a style per line color, and that it can be read back
+func TestJourney(t *testing.T) {
+	var j types.Journey
+	if err := json.Unmarshal([]byte(testJourney), &j); err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Journey(j, paris).Encode(&buf); err != nil {
+		t.Fatalf("error in Encode: %v", err)
+	}
+	var k KML
+	if err := xml.Unmarshal(buf.Bytes(), &k); err != nil {
+		t.Fatalf("error while reading the document back: %v\n%s", err, buf.Bytes())
+	}
+
+	doc := k.Document
+	if k.Namespace != Namespace || doc.Name != "Bastille → Cité" {
+		t.Errorf("unexpected document: %+v", k)
+	}
+	// The colors of the line 1, of the transfer which has no line, and of the line 4 as aabbggrr
+	if len(doc.Styles) != 3 || doc.Styles[0].ID != "line-ff00ceff" || doc.Styles[1].ID != "line-ff555555" || doc.Styles[2].LineStyle.Color != "ff8d41be" {
+		t.Errorf("unexpected styles: %+v", doc.Styles)
+	}
+
+	// Each ride & its two stop points, with the transfer in between
+	if len(doc.Placemarks) != 7 {
+		t.Fatalf("expected 7 placemarks, got %+v", doc.Placemarks)
+	}
+	first, transfer, second := doc.Placemarks[0], doc.Placemarks[3], doc.Placemarks[4]
+	if first.Name != "Metro 1 to La Défense" || first.StyleURL != "#line-ff00ceff" || first.LineString == nil || first.LineString.Coordinates != "2.369,48.853 2.347,48.858" {
+		t.Errorf("unexpected first ride: %+v", first)
+	}
+	if transfer.Name != "transfer" || transfer.StyleURL != "#line-ff555555" {
+		t.Errorf("unexpected transfer: %+v", transfer)
+	}
+	if second.Name != "Metro 4 to Mairie de Montrouge" || second.Description != "Châtelet → Cité" || second.StyleURL != "#line-ff8d41be" {
+		t.Errorf("unexpected second ride: %+v", second)
+	}
+
+	// Paris is two hours ahead of UTC in summer
+	if pm := doc.Placemarks[5]; pm.Name != "Châtelet" || pm.TimeStamp == nil || pm.TimeStamp.When != "2017-06-29T09:19:00+02:00" {
+		t.Errorf("unexpected stop point placemark: %+v", pm)
+	}
+}
@@ -15,8 +15,8 @@ var (
 	testDataPath     string
 )
 
-func init() {
-	flag.Parse()
+// setupTestData loads the test data from the directory given by the path flag
+func setupTestData() {
 	// If the given path is absolute, then use it as-is
 	if filepath.IsAbs(*testDataPathFlag) {
 		testDataPath = *testDataPathFlag
@@ -57,6 +57,9 @@ var typesList = []string{
 	"coverage",
 	"places",
 	"connections",
+	"status",
+	"regionstatus",
+	"geostatus",
 }
 
 // listCategoryDirs retrieves the subdirectories under the main testdata directory
@@ -3,6 +3,8 @@ package navitia
 import (
 	"net/url"
 
+	"golang.org/x/text/language"
+
 	"github.com/govitia/navitia/types"
 	"github.com/govitia/navitia/utils"
 )
@@ -54,6 +56,9 @@ type PlacesRequest struct {
 
 	// Maximum amount of results
 	Count uint
+
+	// Language of the texts of the reply, such as the names of the places, if the instance supports it
+	Language language.Tag
 }
 
 // toURL formats a Places request to url
@@ -64,6 +69,10 @@ func (req PlacesRequest) toURL() (url.Values, error) {
 	rb.AddStringSlice("type[]", req.Types)
 	rb.AddStringSlice("admin_uri[]", req.AdminURI)
 
+	if req.Around != (types.Coordinates{}) {
+		rb.AddString("from", string(req.Around.ID()))
+	}
+
 	if !req.Geo {
 		rb.AddString("disable_geojson", "true")
 	}
@@ -71,5 +80,7 @@ func (req PlacesRequest) toURL() (url.Values, error) {
 	if req.Count != 0 {
 		rb.AddUInt("count", req.Count)
 	}
+
+	rb.AddLanguage("language", req.Language)
 	return rb.Values(), nil
 }
@@ -0,0 +1,350 @@
+package navitia
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/govitia/navitia/types"
+)
+
+// defaultPoolCooldown is the time a session is put aside after being throttled or after a server failure
+const defaultPoolCooldown = time.Minute
+
+// ErrPoolExhausted is returned by a SessionPool when none of its sessions is available to serve a request.
+var ErrPoolExhausted = errors.New("no session available in pool")
+
+// A PoolStrategy defines how a SessionPool picks the Session serving a request.
+type PoolStrategy int
+
+// PoolXXX are the known strategies of a SessionPool
+const (
+	// PoolRoundRobin rotates through the sessions able to serve the request.
+	PoolRoundRobin PoolStrategy = iota
+
+	// PoolByRegion first tries the sessions dedicated to the requested region, then rotates through the generic ones.
+	PoolByRegion
+)
+
+// A PoolMember configures a Session added to a SessionPool.
+type PoolMember struct {
+	Session *Session
+
+	// Regions restricts the member to these regions, which is useful for self-hosted instances.
+	// If empty, the member serves every request, global ones included.
+	Regions []types.ID
+
+	// Quota is the maximum amount of requests the member may serve during each QuotaPeriod.
+	// If Quota is 0, the member is unlimited.
+	Quota       uint
+	QuotaPeriod time.Duration
+}
+
+// KeyUsage reports the usage of a member of a SessionPool.
+//
+// It doesn't hold the API key itself, so that it can be logged safely.
+type KeyUsage struct {
+	// Member is the index of the member, in the order they were given to NewSessionPool
+	Member int
+
+	APIURL string
+
+	// MaskedKey is the API key of the member with all but its last 4 characters masked, such as "********c0de"
+	MaskedKey string
+
+	Requests  uint // Amount of requests served
+	Failures  uint // Amount of requests which failed with a server error (5xx)
+	Throttled uint // Amount of requests rejected because of rate-limiting (429)
+
+	// Amount of requests served since PeriodStart, used for quota accounting
+	PeriodRequests uint
+	PeriodStart    time.Time
+
+	// Disabled is true when the key has been refused by the server (401).
+	// It stays disabled until the pool is Reset.
+	Disabled bool
+
+	// The member won't be used before BackoffUntil
+	BackoffUntil time.Time
+
+	// The last error returned by a request served by this member
+	LastError error
+}
+
+// poolMember is a PoolMember along with its usage
+type poolMember struct {
+	PoolMember
+	usage KeyUsage
+}
+
+// available reports whether the member can serve a request at the given time.
+func (m *poolMember) available(now time.Time) bool {
+	if m.usage.Disabled || now.Before(m.usage.BackoffUntil) {
+		return false
+	}
+	if m.Quota != 0 && m.usage.PeriodRequests >= m.Quota && now.Before(m.usage.PeriodStart.Add(m.QuotaPeriod)) {
+		return false
+	}
+	return true
+}
+
+// serves reports whether the member is dedicated to the given region, and whether it can serve it at all.
+func (m *poolMember) serves(region types.ID) (dedicated bool, ok bool) {
+	if len(m.Regions) == 0 {
+		return false, true
+	}
+	for _, r := range m.Regions {
+		if r == region {
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// A SessionPool wraps multiple sessions (for example several API keys, or a self-hosted Navitia instance), and spreads the requests between them.
+//
+// When a session fails with a 401, 429 or 5xx RemoteError, the pool fails over to the next available one,
+// and puts the failing one aside. It is thread-safe.
+type SessionPool struct {
+	// Strategy used to pick the sessions
+	Strategy PoolStrategy
+
+	// Cooldown is the time a session is put aside after being throttled or after a server failure.
+	Cooldown time.Duration
+
+	mu      sync.Mutex
+	members []*poolMember
+	next    int
+}
+
+// NewSessionPool creates a new pool given a strategy and its members.
+func NewSessionPool(strategy PoolStrategy, members ...PoolMember) (*SessionPool, error) {
+	p := &SessionPool{
+		Strategy: strategy,
+		Cooldown: defaultPoolCooldown,
+		members:  make([]*poolMember, 0, len(members)),
+	}
+	for i, m := range members {
+		if m.Session == nil {
+			return nil, errors.Errorf("member #%d has no session", i)
+		}
+		if m.Quota != 0 && m.QuotaPeriod <= 0 {
+			return nil, errors.Errorf("member #%d has a quota but no quota period", i)
+		}
+		p.members = append(p.members, &poolMember{
+			PoolMember: m,
+			usage:      KeyUsage{Member: i, APIURL: m.Session.APIURL, MaskedKey: maskKey(m.Session.APIKey)},
+		})
+	}
+	return p, nil
+}
+
+// maskKey masks all but the last 4 characters of an API key, and the whole key if it is too short to hide anything
+func maskKey(key string) string {
+	const shown = 4
+	if len(key) <= 2*shown {
+		return strings.Repeat("*", len(key))
+	}
+	return strings.Repeat("*", len(key)-shown) + key[len(key)-shown:]
+}
+
+// pick selects the index of the member serving the next request for the given region, skipping those already tried.
+// It also accounts for the request in the member's quota.
+func (p *SessionPool) pick(region types.ID, tried map[int]bool) (int, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	chosen := -1
+	for n := 0; n < len(p.members); n++ {
+		i := (p.next + n) % len(p.members)
+		m := p.members[i]
+		if tried[i] || !m.available(now) {
+			continue
+		}
+		dedicated, ok := m.serves(region)
+		if !ok {
+			continue
+		}
+		if chosen == -1 {
+			chosen = i
+		}
+		// When routing by region, a dedicated member wins over the first generic one
+		if p.Strategy != PoolByRegion || dedicated {
+			chosen = i
+			break
+		}
+	}
+	if chosen == -1 {
+		return 0, false
+	}
+	p.next = (chosen + 1) % len(p.members)
+
+	// Account for it
+	m := p.members[chosen]
+	if m.Quota != 0 && !now.Before(m.usage.PeriodStart.Add(m.QuotaPeriod)) {
+		m.usage.PeriodStart = now
+		m.usage.PeriodRequests = 0
+	}
+	m.usage.PeriodRequests++
+	m.usage.Requests++
+
+	return chosen, true
+}
+
+// record records the outcome of a request served by the i-th member, and reports whether we should fail over.
+func (p *SessionPool) record(i int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	m := p.members[i]
+	m.usage.LastError = err
+
+	var remoteErr *RemoteError
+	if !errors.As(err, &remoteErr) {
+		return false
+	}
+
+	switch code := remoteErr.StatusCode; {
+	case code == http.StatusUnauthorized:
+		m.usage.Disabled = true
+	case code == http.StatusTooManyRequests:
+		m.usage.Throttled++
+		m.usage.BackoffUntil = time.Now().Add(p.Cooldown)
+	case code >= http.StatusInternalServerError:
+		m.usage.Failures++
+		m.usage.BackoffUntil = time.Now().Add(p.Cooldown)
+	default:
+		return false
+	}
+	return true
+}
+
+// Do calls f with a session able to serve the given region, failing over to the next one if needed.
+// If region is empty, the request is considered global, and only members without region restrictions are used.
+//
+// The error returned by f is returned as-is, unless no session could be found in which case ErrPoolExhausted is returned.
+//
+// Example:
+//
+//	err := pool.Do(ctx, "fr-idf", func(s *navitia.Session) error {
+//		res, err = s.Scope("fr-idf").Journeys(ctx, req)
+//		return err
+//	})
+func (p *SessionPool) Do(ctx context.Context, region types.ID, f func(s *Session) error) error {
+	tried := make(map[int]bool, len(p.members))
+	var err error
+	for {
+		// Check for cancellation
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		i, ok := p.pick(region, tried)
+		if !ok {
+			if err != nil {
+				return err
+			}
+			return ErrPoolExhausted
+		}
+		tried[i] = true
+
+		err = f(p.members[i].Session)
+		if !p.record(i, err) {
+			return err
+		}
+	}
+}
+
+// Journeys computes a list of journeys according to the parameters given, using one of the sessions of the pool.
+//
+// The request is scoped to the given region, so that the members dedicated to it can serve it.
+// If region is empty, the request is global and only served by the members without region restrictions,
+// their session scoping it automatically if its AutoScope is enabled.
+func (p *SessionPool) Journeys(ctx context.Context, region types.ID, req JourneyRequest) (*JourneyResults, error) {
+	var res *JourneyResults
+	err := p.Do(ctx, region, func(s *Session) error {
+		var err error
+		if region != "" {
+			res, err = s.Scope(region).Journeys(ctx, req)
+		} else {
+			res, err = s.Journeys(ctx, req)
+		}
+		return err
+	})
+	return res, err
+}
+
+// Places searches in all geographical objects using their names, using one of the sessions of the pool.
+//
+// As with Journeys, the request is scoped to the given region, or else global.
+func (p *SessionPool) Places(ctx context.Context, region types.ID, req PlacesRequest) (*PlacesResults, error) {
+	var res *PlacesResults
+	err := p.Do(ctx, region, func(s *Session) error {
+		var err error
+		if region != "" {
+			res, err = s.Scope(region).Places(ctx, req)
+		} else {
+			res, err = s.Places(ctx, req)
+		}
+		return err
+	})
+	return res, err
+}
+
+// RegionByID provides information about the region, using one of the sessions serving it.
+func (p *SessionPool) RegionByID(ctx context.Context, req RegionRequest, id types.ID) (*RegionResults, error) {
+	var res *RegionResults
+	err := p.Do(ctx, id, func(s *Session) error {
+		var err error
+		res, err = s.RegionByID(ctx, req, id)
+		return err
+	})
+	return res, err
+}
+
+// Regions lists the areas covered by the Navitia API, using one of the sessions of the pool.
+// As this is a global request, only the members without region restrictions serve it.
+func (p *SessionPool) Regions(ctx context.Context, req RegionRequest) (*RegionResults, error) {
+	var res *RegionResults
+	err := p.Do(ctx, "", func(s *Session) error {
+		var err error
+		res, err = s.Regions(ctx, req)
+		return err
+	})
+	return res, err
+}
+
+// Usage returns a snapshot of the usage of each member of the pool, in the order they were given.
+func (p *SessionPool) Usage() []KeyUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	usage := make([]KeyUsage, len(p.members))
+	for i, m := range p.members {
+		usage[i] = m.usage
+	}
+	return usage
+}
+
+// Reset re-enables every member of the pool, clearing their backoff and quota accounting.
+// The request counters are kept.
+func (p *SessionPool) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, m := range p.members {
+		m.usage.Disabled = false
+		m.usage.BackoffUntil = time.Time{}
+		m.usage.PeriodRequests = 0
+		m.usage.PeriodStart = time.Time{}
+	}
+}
@@ -0,0 +1,149 @@
+package navitia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// newPoolTestServer creates a test server answering every request with the given status code
+func newPoolTestServer(t *testing.T, status int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_, _ = w.Write([]byte(`{"regions": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"message": "failure"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestSessionPool_Failover checks that a SessionPool fails over to the next session and accounts for it
+func TestSessionPool_Failover(t *testing.T) {
+	throttled := newPoolTestServer(t, http.StatusTooManyRequests)
+	unauthorized := newPoolTestServer(t, http.StatusUnauthorized)
+	ok := newPoolTestServer(t, http.StatusOK)
+
+	var members []PoolMember
+	for _, srv := range []*httptest.Server{throttled, unauthorized, ok} {
+		s, err := NewCustom("key", srv.URL, srv.Client())
+		if err != nil {
+			t.Fatalf("error in NewCustom: %v", err)
+		}
+		members = append(members, PoolMember{Session: s})
+	}
+
+	pool, err := NewSessionPool(PoolRoundRobin, members...)
+	if err != nil {
+		t.Fatalf("error in NewSessionPool: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := pool.Regions(ctx, RegionRequest{}); err != nil {
+		t.Fatalf("expected the pool to fail over, got %v", err)
+	}
+
+	usage := pool.Usage()
+	if usage[0].Throttled != 1 || usage[0].BackoffUntil.IsZero() {
+		t.Errorf("expected the first member to be throttled, got %#v", usage[0])
+	}
+	if !usage[1].Disabled {
+		t.Errorf("expected the second member to be disabled, got %#v", usage[1])
+	}
+	if usage[2].Requests != 1 {
+		t.Errorf("expected the third member to have served 1 request, got %d", usage[2].Requests)
+	}
+
+	// Only the last member is available now
+	if _, err := pool.Regions(ctx, RegionRequest{}); err != nil {
+		t.Fatalf("error in second call: %v", err)
+	}
+	if got := pool.Usage()[2].Requests; got != 2 {
+		t.Errorf("expected the third member to have served 2 requests, got %d", got)
+	}
+}
+
+// TestSessionPool_Quota checks that an exhausted quota makes the pool unavailable
+func TestSessionPool_Quota(t *testing.T) {
+	srv := newPoolTestServer(t, http.StatusOK)
+	s, err := NewCustom("key", srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("error in NewCustom: %v", err)
+	}
+
+	pool, err := NewSessionPool(PoolByRegion, PoolMember{Session: s, Quota: 1, QuotaPeriod: time.Hour})
+	if err != nil {
+		t.Fatalf("error in NewSessionPool: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := pool.Regions(ctx, RegionRequest{}); err != nil {
+		t.Fatalf("error in first call: %v", err)
+	}
+	if _, err := pool.Regions(ctx, RegionRequest{}); err != ErrPoolExhausted {
+		t.Fatalf("expected ErrPoolExhausted, got %v", err)
+	}
+}
+
+// TestSessionPool_ByRegion checks that scoped requests are served by the members dedicated to their region,
+// and that the usage doesn't leak the API keys
+func TestSessionPool_ByRegion(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		paths = make(map[string][]string)
+	)
+	newServer := func(name string) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			paths[name] = append(paths[name], r.URL.Path)
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+	generic, regional := newServer("generic"), newServer("regional")
+
+	genericSession, _ := NewCustom("generic-secret-key", generic.URL, generic.Client())
+	regionalSession, _ := NewCustom("regional-secret-c0de", regional.URL, regional.Client())
+	pool, err := NewSessionPool(PoolByRegion,
+		PoolMember{Session: genericSession},
+		PoolMember{Session: regionalSession, Regions: []types.ID{"fr-idf"}},
+	)
+	if err != nil {
+		t.Fatalf("error in NewSessionPool: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := pool.Journeys(ctx, "fr-idf", JourneyRequest{}); err != nil {
+		t.Fatalf("error in scoped Journeys: %v", err)
+	}
+	if _, err := pool.Places(ctx, "", PlacesRequest{Query: "Bercy"}); err != nil {
+		t.Fatalf("error in global Places: %v", err)
+	}
+
+	if got := paths["regional"]; len(got) != 1 || got[0] != "/coverage/fr-idf/journeys" {
+		t.Errorf("expected the regional member to serve the scoped request, got %v", got)
+	}
+	if got := paths["generic"]; len(got) != 1 || got[0] != "/places" {
+		t.Errorf("expected the generic member to serve the global request, got %v", got)
+	}
+
+	usage := pool.Usage()
+	if usage[1].Member != 1 || usage[1].MaskedKey != "****************c0de" {
+		t.Errorf("unexpected usage of the regional member: %#v", usage[1])
+	}
+	if s := fmt.Sprintf("%#v", usage); strings.Contains(s, "secret") {
+		t.Errorf("usage leaks the API keys: %s", s)
+	}
+}
@@ -14,6 +14,9 @@ type ContainerConf struct {
 	Quality *color.Color
 	Type    *color.Color
 	Name    *color.Color
+
+	// Locale of the labels, LocaleEnglish if left empty
+	Locale Locale
 }
 
 // DefaultContainerConf holds a default, quite good configuration
@@ -23,12 +26,10 @@ var DefaultContainerConf = ContainerConf{
 	Name:    color.New(color.FgBlue),
 }
 
-var placeTypeToName = map[string]string{
-	"address":               "Address",
-	"poi":                   "Point Of Interest",
-	"stop_area":             "Stop Area",
-	"stop_point":            "Stop Point",
-	"administrative_region": "Administrative Region",
+// WithLocale returns a copy of the configuration using the given locale
+func (conf ContainerConf) WithLocale(l Locale) ContainerConf {
+	conf.Locale = l
+	return conf
 }
 
 // ContainerWrite writes a pretty-printed account of a types.Container to out.
@@ -41,7 +42,7 @@ func (conf ContainerConf) ContainerWrite(c *types.Container, out io.Writer) erro
 	const msgFmt = "(%s)\t%s"
 	msg += fmt.Sprintf(
 		msgFmt,
-		conf.Type.Sprint(placeTypeToName[c.EmbeddedType]),
+		conf.Type.Sprint(conf.Locale.PlaceType(c.EmbeddedType)),
 		conf.Name.Sprint(c.Name),
 	)
 
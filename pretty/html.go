@@ -0,0 +1,74 @@
+package pretty
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/govitia/navitia"
+	"github.com/govitia/navitia/types"
+)
+
+// HTMLRenderer writes results as self-contained HTML documents, with their styles inlined in style attributes
+// and lines shown as badges in their colors, so that they can be sent as emails.
+type HTMLRenderer struct {
+	// Locale of the labels, LocaleEnglish if left empty
+	Locale Locale
+}
+
+// WriteJourneys writes the journeys of jr to out, one table per journey
+func (r HTMLRenderer) WriteJourneys(jr *navitia.JourneyResults, out io.Writer) error {
+	return htmlTemplate.Execute(out, journeysDocument(r.Locale, newJourneys(jr, nil)))
+}
+
+// WritePlaces writes the places of pr to out
+func (r HTMLRenderer) WritePlaces(pr *navitia.PlacesResults, out io.Writer) error {
+	return htmlTemplate.Execute(out, placesDocument(r.Locale, newPlaces(pr)))
+}
+
+// WriteDepartures writes the departures of cr to out
+func (r HTMLRenderer) WriteDepartures(cr *navitia.ConnectionsResults, out io.Writer) error {
+	return htmlTemplate.Execute(out, departuresDocument(r.Locale, newDepartures(cr, nil)))
+}
+
+// WriteDisruptions writes ds to out
+func (r HTMLRenderer) WriteDisruptions(ds []types.Disruption, out io.Writer) error {
+	return htmlTemplate.Execute(out, disruptionsDocument(r.Locale, newDisruptions(ds, nil)))
+}
+
+// badgeStyle is the style of the badges of lines, before their colors
+const badgeStyle = "display:inline-block;min-width:1.5em;padding:0 0.4em;border-radius:0.3em;font-weight:bold;text-align:center;"
+
+// lineStyle returns the inline style of the badge of a line, in its colors if it has some
+func lineStyle(l *Line) template.CSS {
+	// The colors are formatted by types.HexColor, so they are safe to inline
+	background, text := "#555555", "#ffffff"
+	if l.Color != "" {
+		background = l.Color
+	}
+	if l.TextColor != "" {
+		text = l.TextColor
+	}
+	return template.CSS(badgeStyle + "background-color:" + background + ";color:" + text + ";")
+}
+
+// htmlTemplate lays out a document
+var htmlTemplate = template.Must(template.New("document").Funcs(template.FuncMap{
+	"lineStyle": lineStyle,
+}).Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body style="font-family:sans-serif;color:#222222;">
+<h1>{{.Title}}</h1>
+{{range .Tables}}{{if .Title}}<h2>{{.Title}}</h2>
+{{end}}<table style="border-collapse:collapse;margin-bottom:1.5em;">
+<thead><tr>{{range .Headers}}<th style="border:1px solid #cccccc;padding:0.3em 0.6em;text-align:left;background-color:#f4f4f4;">{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{range .Rows}}<tr>{{range .}}<td style="border:1px solid #cccccc;padding:0.3em 0.6em;text-align:left;">{{with .Line}}<span style="{{lineStyle .}}">{{.Badge}}</span>{{end}}{{if and .Line .Text}} {{end}}{{.Text}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+{{end}}</body>
+</html>
+`))
@@ -6,31 +6,50 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/fatih/color"
 
 	"github.com/govitia/navitia/types"
 )
 
-const timeLayout = "15:04"
-
 // JourneyConf stores configuration for pretty-printing a types.Journey
 type JourneyConf struct {
-	Departure      *color.Color
-	Arrival        *color.Color
-	Duration       *color.Color
+	Departure *color.Color
+	Arrival   *color.Color
+	Duration  *color.Color
+
+	// DateTimeLayout overrides the time layout of the locale if given
 	DateTimeLayout string
 
+	// Locale of the labels, LocaleEnglish if left empty.
+	// It is used for the sections too, unless Section has a locale of its own.
+	Locale Locale
+
 	Section SectionConf
 }
 
 // DefaultJourneyConf holds a default, quite good configuration
 var DefaultJourneyConf = JourneyConf{
-	Departure:      color.New(color.FgRed),
-	Arrival:        color.New(color.FgRed),
-	Duration:       color.New(color.FgMagenta),
-	DateTimeLayout: timeLayout,
-	Section:        DefaultSectionConf,
+	Departure: color.New(color.FgRed),
+	Arrival:   color.New(color.FgRed),
+	Duration:  color.New(color.FgMagenta),
+	Section:   DefaultSectionConf,
+}
+
+// WithLocale returns a copy of the configuration using the given locale, for its sections too
+func (conf JourneyConf) WithLocale(l Locale) JourneyConf {
+	conf.Locale = l
+	conf.Section = conf.Section.WithLocale(l)
+	return conf
+}
+
+// time formats the given time of day
+func (conf JourneyConf) time(t time.Time) string {
+	if conf.DateTimeLayout != "" {
+		return t.Format(conf.DateTimeLayout)
+	}
+	return conf.Locale.Time(t)
 }
 
 // PrettyWrite writes a pretty-printed types.Journey to out
@@ -39,11 +58,17 @@ func (conf JourneyConf) PrettyWrite(j *types.Journey, out io.Writer) error {
 	const msgFmt = "%s ➡️ %s | %s\n"
 	msg := fmt.Sprintf(
 		msgFmt,
-		conf.Departure.Sprint(j.Departure.Format(conf.DateTimeLayout)),
-		conf.Arrival.Sprint(j.Arrival.Format(conf.DateTimeLayout)),
-		conf.Duration.Sprint(j.Duration.String()),
+		conf.Departure.Sprint(conf.time(j.Departure)),
+		conf.Arrival.Sprint(conf.time(j.Arrival)),
+		conf.Duration.Sprint(conf.Locale.Duration(j.Duration)),
 	)
 
+	// The sections follow the locale of the journey unless told otherwise
+	section := conf.Section
+	if section.Locale.isZero() {
+		section.Locale = conf.Locale
+	}
+
 	// Buffers to line-up the reads, sequentially
 	buffers := make([]io.Reader, len(j.Sections))
 
@@ -61,7 +86,7 @@ func (conf JourneyConf) PrettyWrite(j *types.Journey, out io.Writer) error {
 		// Launch !
 		go func(s types.Section) {
 			defer wg.Done()
-			err := conf.Section.PrettyWrite(&s, buf)
+			err := section.PrettyWrite(&s, buf)
 
 			// TODO: Deal with errors
 			_ = err
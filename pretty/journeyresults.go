@@ -23,6 +23,12 @@ var DefaultJourneyResultsConf = JourneyResultsConf{
 	Journey: DefaultJourneyConf,
 }
 
+// WithLocale returns a copy of the configuration using the given locale for its journeys
+func (conf JourneyResultsConf) WithLocale(l Locale) JourneyResultsConf {
+	conf.Journey = conf.Journey.WithLocale(l)
+	return conf
+}
+
 // PrettyWrite writes a pretty-printed navitia.JourneyResults to out
 func (conf JourneyResultsConf) PrettyWrite(jr *navitia.JourneyResults, out io.Writer) error {
 	// Buffers to line-up the reads, sequentially
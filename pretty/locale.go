@@ -0,0 +1,242 @@
+package pretty
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/govitia/navitia/types"
+)
+
+// A Locale holds the texts and layouts used when pretty-printing for a given language.
+//
+// The zero value of Locale is LocaleEnglish.
+type Locale struct {
+	// Tag is the language of the locale, to be given as the Language of a request so that the names
+	// returned by navitia match the labels
+	Tag language.Tag
+
+	// TimeLayout is the time.Format layout used for times of day, "15:04" or "3:04 PM" for instance
+	TimeLayout string
+
+	// PlacesFound is the format of the count of places found, taking an integer
+	PlacesFound string
+
+	// PlaceTypes maps an embedded type (such as types.EmbeddedStopArea) to its label
+	PlaceTypes map[string]string
+
+	// Modes maps a non-public-transport mode (such as types.ModeWalking) or a section type without mode
+	// (such as types.SectionTransfer) to its label, used when emoji are disabled
+	Modes map[string]string
+
+	// Labels are the titles & column headers of the Markdown & HTML renderers
+	Labels Labels
+}
+
+// Labels holds the titles & column headers of the tables written by the Markdown & HTML renderers
+type Labels struct {
+	// Titles of the documents
+	Journeys    string
+	Places      string
+	Departures  string
+	Disruptions string
+
+	// Column headers
+	Mode      string
+	Line      string
+	From      string
+	To        string
+	Departure string
+	Arrival   string
+	Duration  string
+	Name      string
+	Type      string
+	Direction string
+	Stop      string
+	Platform  string
+	Delay     string
+	Severity  string
+	Effect    string
+	Period    string
+	Impacted  string
+	Message   string
+}
+
+// LocaleEnglish is the english locale, with a 24-hour clock
+var LocaleEnglish = Locale{
+	Tag:         language.English,
+	TimeLayout:  "15:04",
+	PlacesFound: "(%d places found)",
+	PlaceTypes: map[string]string{
+		types.EmbeddedAddress:   "Address",
+		types.EmbeddedPOI:       "Point Of Interest",
+		types.EmbeddedStopArea:  "Stop Area",
+		types.EmbeddedStopPoint: "Stop Point",
+		types.EmbeddedAdmin:     "Administrative Region",
+	},
+	Modes: map[string]string{
+		types.ModeWalking:   "Walk",
+		types.ModeBike:      "Bike",
+		types.ModeBikeShare: "Bike sharing",
+		types.ModeCar:       "Car",
+
+		string(types.SectionTransfer): "Transfer",
+		string(types.SectionWaiting):  "Wait",
+	},
+	Labels: Labels{
+		Journeys:    "Journeys",
+		Places:      "Places",
+		Departures:  "Departures",
+		Disruptions: "Disruptions",
+		Mode:        "Mode",
+		Line:        "Line",
+		From:        "From",
+		To:          "To",
+		Departure:   "Departure",
+		Arrival:     "Arrival",
+		Duration:    "Duration",
+		Name:        "Name",
+		Type:        "Type",
+		Direction:   "Direction",
+		Stop:        "Stop",
+		Platform:    "Platform",
+		Delay:       "Delay",
+		Severity:    "Severity",
+		Effect:      "Effect",
+		Period:      "Period",
+		Impacted:    "Impacted",
+		Message:     "Message",
+	},
+}
+
+// LocaleFrench is the french locale
+var LocaleFrench = Locale{
+	Tag:         language.French,
+	TimeLayout:  "15:04",
+	PlacesFound: "(%d lieux trouvés)",
+	PlaceTypes: map[string]string{
+		types.EmbeddedAddress:   "Adresse",
+		types.EmbeddedPOI:       "Point d'intérêt",
+		types.EmbeddedStopArea:  "Zone d'arrêt",
+		types.EmbeddedStopPoint: "Point d'arrêt",
+		types.EmbeddedAdmin:     "Région administrative",
+	},
+	Modes: map[string]string{
+		types.ModeWalking:   "Marche",
+		types.ModeBike:      "Vélo",
+		types.ModeBikeShare: "Vélo en libre-service",
+		types.ModeCar:       "Voiture",
+
+		string(types.SectionTransfer): "Correspondance",
+		string(types.SectionWaiting):  "Attente",
+	},
+	Labels: Labels{
+		Journeys:    "Itinéraires",
+		Places:      "Lieux",
+		Departures:  "Départs",
+		Disruptions: "Perturbations",
+		Mode:        "Mode",
+		Line:        "Ligne",
+		From:        "De",
+		To:          "À",
+		Departure:   "Départ",
+		Arrival:     "Arrivée",
+		Duration:    "Durée",
+		Name:        "Nom",
+		Type:        "Type",
+		Direction:   "Direction",
+		Stop:        "Arrêt",
+		Platform:    "Quai",
+		Delay:       "Retard",
+		Severity:    "Gravité",
+		Effect:      "Effet",
+		Period:      "Période",
+		Impacted:    "Concerne",
+		Message:     "Message",
+	},
+}
+
+// locales lists the supported locales, the first one being the default
+var locales = []Locale{LocaleEnglish, LocaleFrench}
+
+// localeMatcher matches the requested languages with the supported locales
+var localeMatcher = func() language.Matcher {
+	tags := make([]language.Tag, len(locales))
+	for i, l := range locales {
+		tags[i] = l.Tag
+	}
+	return language.NewMatcher(tags)
+}()
+
+// LocaleFor returns the supported locale closest to the given language, defaulting to LocaleEnglish.
+//
+// For american english, times are formatted with a 12-hour clock. It must be asked for explicitly, such as "en-US":
+// "en" alone gets the 24-hour clock of LocaleEnglish.
+func LocaleFor(tag language.Tag) Locale {
+	_, i, _ := localeMatcher.Match(tag)
+	l := locales[i]
+
+	if region, conf := tag.Region(); l.Tag == language.English && conf == language.Exact && region.String() == "US" {
+		l.Tag = language.AmericanEnglish
+		l.TimeLayout = "3:04 PM"
+	}
+
+	return l
+}
+
+// isZero reports whether the locale is the zero value, that is left empty
+func (l Locale) isZero() bool {
+	return l.Tag == language.Und && l.TimeLayout == ""
+}
+
+// or returns the locale, or LocaleEnglish if it is the zero value
+func (l Locale) or() Locale {
+	if l.isZero() {
+		return LocaleEnglish
+	}
+	return l
+}
+
+// Time formats the time of day of t
+func (l Locale) Time(t time.Time) string {
+	return t.Format(l.or().TimeLayout)
+}
+
+// Duration formats d rounded to the minute, such as "5 min" or "1 h 05 min"
+func (l Locale) Duration(d time.Duration) string {
+	minutes := int64(d.Round(time.Minute) / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("%d min", minutes)
+	}
+	return fmt.Sprintf("%d h %02d min", minutes/60, minutes%60)
+}
+
+// PlaceType returns the label of the given embedded type, or the embedded type itself if it is unknown
+func (l Locale) PlaceType(embeddedType string) string {
+	if name, ok := l.or().PlaceTypes[embeddedType]; ok {
+		return name
+	}
+	return embeddedType
+}
+
+// Mode returns the label of the given mode or section type, or the mode itself if it is unknown
+func (l Locale) Mode(mode string) string {
+	if name, ok := l.or().Modes[mode]; ok {
+		return name
+	}
+	return mode
+}
+
+// Count formats the count of places found
+func (l Locale) Count(n int) string {
+	return fmt.Sprintf(l.or().PlacesFound, n)
+}
+
+// labels returns the labels of the locale, or those of LocaleEnglish if they are empty
+func (l Locale) labels() Labels {
+	if l.Labels == (Labels{}) {
+		return LocaleEnglish.Labels
+	}
+	return l.Labels
+}
@@ -0,0 +1,132 @@
+package pretty
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"golang.org/x/text/language"
+
+	"github.com/govitia/navitia/types"
+)
+
+func init() {
+	// Write plain texts, so that they can be compared
+	color.NoColor = true
+}
+
+// TestLocaleFor checks the matching of languages with the supported locales
+func TestLocaleFor(t *testing.T) {
+	tests := []struct {
+		tag        string
+		wantTag    language.Tag
+		wantLayout string
+	}{
+		{"en", language.English, "15:04"},
+		{"en-GB", language.English, "15:04"},
+		{"en-US", language.AmericanEnglish, "3:04 PM"},
+		{"fr", language.French, "15:04"},
+		{"fr-CA", language.French, "15:04"},
+		{"de", language.English, "15:04"},
+	}
+	for _, test := range tests {
+		l := LocaleFor(language.MustParse(test.tag))
+		if l.Tag != test.wantTag || l.TimeLayout != test.wantLayout {
+			t.Errorf("%s: got %s with %q, want %s with %q", test.tag, l.Tag, l.TimeLayout, test.wantTag, test.wantLayout)
+		}
+	}
+}
+
+// TestLocale_Duration checks that durations are rounded to the minute
+func TestLocale_Duration(t *testing.T) {
+	tests := map[time.Duration]string{
+		5 * time.Minute:                 "5 min",
+		4*time.Minute + 31*time.Second:  "5 min",
+		time.Hour + 5*time.Minute:       "1 h 05 min",
+		2*time.Hour + 29*time.Second:    "2 h 00 min",
+		59*time.Minute + 40*time.Second: "1 h 00 min",
+	}
+	for d, want := range tests {
+		if got := LocaleFrench.Duration(d); got != want {
+			t.Errorf("%s: got %q, want %q", d, got, want)
+		}
+	}
+}
+
+// TestLocale_zero checks that the zero value of Locale is LocaleEnglish
+func TestLocale_zero(t *testing.T) {
+	var l Locale
+	if got := l.Time(time.Date(2017, 4, 29, 14, 7, 0, 0, time.UTC)); got != "14:07" {
+		t.Errorf("unexpected time: %q", got)
+	}
+	if got := l.PlaceType(types.EmbeddedStopArea); got != "Stop Area" {
+		t.Errorf("unexpected place type: %q", got)
+	}
+	if got := l.Count(3); got != "(3 places found)" {
+		t.Errorf("unexpected count: %q", got)
+	}
+}
+
+// TestWithLocale checks that the locale is given to the nested configurations
+func TestWithLocale(t *testing.T) {
+	jr := DefaultJourneyResultsConf.WithLocale(LocaleFrench)
+	if jr.Journey.Locale.Tag != language.French || jr.Journey.Section.Locale.Tag != language.French {
+		t.Errorf("unexpected journey configuration: %+v", jr.Journey)
+	}
+
+	pr := DefaultPlacesResultsConf.WithLocale(LocaleFrench)
+	if pr.Locale.Tag != language.French || pr.Place.Locale.Tag != language.French {
+		t.Errorf("unexpected places configuration: %+v", pr)
+	}
+}
+
+// TestJourneyConf_PrettyWrite_Locale checks that the sections follow the locale of the journey when they have none
+func TestJourneyConf_PrettyWrite_Locale(t *testing.T) {
+	j := types.Journey{
+		Departure: time.Date(2017, 4, 29, 8, 0, 0, 0, time.UTC),
+		Arrival:   time.Date(2017, 4, 29, 8, 5, 0, 0, time.UTC),
+		Duration:  5 * time.Minute,
+		Sections: []types.Section{{
+			Type:     types.SectionStreetNetwork,
+			Mode:     types.ModeWalking,
+			From:     types.Container{Name: "10 Rue de Bercy"},
+			To:       types.Container{Name: "Bercy"},
+			Duration: 5 * time.Minute,
+		}},
+	}
+
+	conf := DefaultJourneyConf
+	conf.Locale = LocaleFrench
+	conf.Section.Emoji = false
+
+	var buf bytes.Buffer
+	if err := conf.PrettyWrite(&j, &buf); err != nil {
+		t.Fatalf("error in PrettyWrite: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Marche (5 min)") {
+		t.Errorf("expected the walk in french, got %q", buf.String())
+	}
+
+	// A locale given to the section wins
+	conf.Section.Locale = LocaleEnglish
+	buf.Reset()
+	if err := conf.PrettyWrite(&j, &buf); err != nil {
+		t.Fatalf("error in PrettyWrite: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Walk (5 min)") {
+		t.Errorf("expected the walk in english, got %q", buf.String())
+	}
+}
+
+// TestSectionConf_Emoji checks that the default configuration shows emoji, and that zero-valued ones name the modes
+func TestSectionConf_Emoji(t *testing.T) {
+	s := &types.Section{Type: types.SectionStreetNetwork, Mode: types.ModeWalking}
+	if got := DefaultSectionConf.mode(s); got != "🚶" {
+		t.Errorf("default configuration: got %q", got)
+	}
+	if got := (SectionConf{}).mode(s); got != "Walk" {
+		t.Errorf("zero configuration: got %q", got)
+	}
+}
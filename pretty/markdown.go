@@ -0,0 +1,92 @@
+package pretty
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/govitia/navitia"
+	"github.com/govitia/navitia/types"
+)
+
+// MarkdownRenderer writes results as Markdown tables, under a title.
+type MarkdownRenderer struct {
+	// Locale of the labels, LocaleEnglish if left empty
+	Locale Locale
+}
+
+// WriteJourneys writes the journeys of jr to out, one table per journey
+func (r MarkdownRenderer) WriteJourneys(jr *navitia.JourneyResults, out io.Writer) error {
+	return writeMarkdown(journeysDocument(r.Locale, newJourneys(jr, nil)), out)
+}
+
+// WritePlaces writes the places of pr to out
+func (r MarkdownRenderer) WritePlaces(pr *navitia.PlacesResults, out io.Writer) error {
+	return writeMarkdown(placesDocument(r.Locale, newPlaces(pr)), out)
+}
+
+// WriteDepartures writes the departures of cr to out
+func (r MarkdownRenderer) WriteDepartures(cr *navitia.ConnectionsResults, out io.Writer) error {
+	return writeMarkdown(departuresDocument(r.Locale, newDepartures(cr, nil)), out)
+}
+
+// WriteDisruptions writes ds to out
+func (r MarkdownRenderer) WriteDisruptions(ds []types.Disruption, out io.Writer) error {
+	return writeMarkdown(disruptionsDocument(r.Locale, newDisruptions(ds, nil)), out)
+}
+
+// markdownEscaper escapes the inline syntax of Markdown, and the characters breaking a table cell
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"<", `\<`,
+	">", `\>`,
+	"[", `\[`,
+	"]", `\]`,
+	"|", `\|`,
+	"\r\n", " ",
+	"\n", " ",
+	"\r", " ",
+)
+
+// writeMarkdown writes doc to out
+func writeMarkdown(doc document, out io.Writer) error {
+	w := bufio.NewWriter(out)
+
+	w.WriteString("## " + doc.Title + "\n")
+	for _, t := range doc.Tables {
+		w.WriteString("\n")
+		if t.Title != "" {
+			w.WriteString("### " + t.Title + "\n\n")
+		}
+
+		w.WriteString("| " + strings.Join(t.Headers, " | ") + " |\n")
+		w.WriteString(strings.Repeat("| --- ", len(t.Headers)) + "|\n")
+
+		for _, row := range t.Rows {
+			for _, c := range row {
+				w.WriteString("| " + markdownCell(c) + " ")
+			}
+			w.WriteString("|\n")
+		}
+	}
+
+	// Errors while writing are kept by the bufio.Writer and returned here
+	return w.Flush()
+}
+
+// markdownCell returns the escaped text of c, the badge of its line being in bold
+func markdownCell(c cell) string {
+	text := markdownEscaper.Replace(c.Text)
+	if c.Line == nil {
+		return text
+	}
+
+	badge := "**" + markdownEscaper.Replace(c.Line.Badge()) + "**"
+	if text == "" {
+		return badge
+	}
+	return badge + " " + text
+}
@@ -16,6 +16,10 @@ import (
 type PlacesResultsConf struct {
 	Count *color.Color
 	Place ContainerConf
+
+	// Locale of the labels, LocaleEnglish if left empty.
+	// It is used for the places too, unless Place has a locale of its own.
+	Locale Locale
 }
 
 // DefaultPlacesResultsConf holds a default, quite good configuration
@@ -24,8 +28,21 @@ var DefaultPlacesResultsConf = PlacesResultsConf{
 	Place: DefaultContainerConf,
 }
 
+// WithLocale returns a copy of the configuration using the given locale, for its places too
+func (conf PlacesResultsConf) WithLocale(l Locale) PlacesResultsConf {
+	conf.Locale = l
+	conf.Place = conf.Place.WithLocale(l)
+	return conf
+}
+
 // PrettyWrite writes a pretty-printed account of a navitia.PlacesResults to out.
 func (conf PlacesResultsConf) PrettyWrite(pr *navitia.PlacesResults, out io.Writer) error {
+	// The places follow the locale of the results unless told otherwise
+	place := conf.Place
+	if place.Locale.isZero() {
+		place.Locale = conf.Locale
+	}
+
 	// Buffers to line-up the reads, sequentially
 	buffers := make([]io.Reader, pr.Len())
 
@@ -45,17 +62,18 @@ func (conf PlacesResultsConf) PrettyWrite(pr *navitia.PlacesResults, out io.Writ
 		go func(p types.Container) {
 			defer wg.Done()
 
-			if err := conf.Place.ContainerWrite(&p, buf); err != nil {
+			if err := place.ContainerWrite(&p, buf); err != nil {
 				panic(err)
 			}
 
-			_, err := buf.WriteString("\n")
-			panic(err)
+			if _, err := buf.WriteString("\n"); err != nil {
+				panic(err)
+			}
 		}(p)
 	}
 
 	// Create the overall message
-	msg := conf.Count.Sprintf("(%d places found)\n", pr.Len())
+	msg := conf.Count.Sprint(conf.Locale.Count(pr.Len())) + "\n"
 
 	// Create the reader
 	readers := append([]io.Reader{strings.NewReader(msg)}, buffers...)
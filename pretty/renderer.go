@@ -0,0 +1,69 @@
+package pretty
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/govitia/navitia"
+	"github.com/govitia/navitia/types"
+)
+
+// A Renderer writes navitia results in a given format, such as MarkdownRenderer, HTMLRenderer or JSONRenderer.
+type Renderer interface {
+	// WriteJourneys writes the journeys of jr to out
+	WriteJourneys(jr *navitia.JourneyResults, out io.Writer) error
+
+	// WritePlaces writes the places of pr to out
+	WritePlaces(pr *navitia.PlacesResults, out io.Writer) error
+
+	// WriteDepartures writes the departures of cr to out
+	WriteDepartures(cr *navitia.ConnectionsResults, out io.Writer) error
+
+	// WriteDisruptions writes ds to out
+	WriteDisruptions(ds []types.Disruption, out io.Writer) error
+}
+
+// JSONRenderer writes results as JSON documents following the normalized schema of Journey, Place, Departure & Disruption.
+//
+// Each document is an object holding the SchemaVersion as "version" and the results under "journeys", "places",
+// "departures" or "disruptions", which are never null.
+// Times are written as RFC 3339 instants, with the offset of Location.
+type JSONRenderer struct {
+	// Indent, if not empty, indents the documents with it
+	Indent string
+
+	// Location is the time zone of the wall-clock times given by Navitia, usually that of the region.
+	// If nil, UTC is used.
+	Location *time.Location
+}
+
+// WriteJourneys writes the journeys of jr to out
+func (r JSONRenderer) WriteJourneys(jr *navitia.JourneyResults, out io.Writer) error {
+	return r.write("journeys", newJourneys(jr, r.Location), out)
+}
+
+// WritePlaces writes the places of pr to out
+func (r JSONRenderer) WritePlaces(pr *navitia.PlacesResults, out io.Writer) error {
+	return r.write("places", newPlaces(pr), out)
+}
+
+// WriteDepartures writes the departures of cr to out
+func (r JSONRenderer) WriteDepartures(cr *navitia.ConnectionsResults, out io.Writer) error {
+	return r.write("departures", newDepartures(cr, r.Location), out)
+}
+
+// WriteDisruptions writes ds to out
+func (r JSONRenderer) WriteDisruptions(ds []types.Disruption, out io.Writer) error {
+	return r.write("disruptions", newDisruptions(ds, r.Location), out)
+}
+
+// write encodes the document holding the given results under key
+func (r JSONRenderer) write(key string, results interface{}, out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", r.Indent)
+	return enc.Encode(map[string]interface{}{
+		"version": SchemaVersion,
+		key:       results,
+	})
+}
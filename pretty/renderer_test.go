@@ -0,0 +1,231 @@
+package pretty
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/govitia/navitia"
+	"github.com/govitia/navitia/types"
+)
+
+// testDepartures is a delayed departure of the metro 1 at Bastille
+const testDepartures = `{
+	"departures": [{
+		"display_informations": {"code": "1", "commercial_mode": "Metro", "physical_mode": "Métro", "direction": "La Défense", "color": "FFCE00", "text_color": "000000"},
+		"stop_point": {"id": "stop_point:1", "name": "Bastille", "platform_code": "A"},
+		"stop_date_time": {"departure_date_time": "20170629T091200", "base_departure_date_time": "20170629T091000", "data_freshness": "realtime"}
+	}]
+}`
+
+// testDisruptions is a closure of the metro 1 overnight, whose only message is HTML
+const testDisruptions = `[{
+	"id": "disruption:1",
+	"status": "future",
+	"severity": {"name": "blocking", "effect": "NO_SERVICE", "color": "FF0000", "priority": 0},
+	"application_periods": [{"begin": "20170629T220000", "end": "20170630T050000"}],
+	"messages": [{"text": "<p>Trafic <b>interrompu</b> entre Bastille &amp; Nation_</p>", "channel": {"id": "channel:1", "name": "web", "content_type": "text/html"}}],
+	"impacted_objects": [{"pt_object": {"id": "line:1", "name": "Métro 1 [Château de Vincennes | La Défense]", "embedded_type": "line"}}]
+}]`
+
+// testJourneys is a journey from an address to another through the metro 1, waiting at Bastille,
+// the last walk leading to an unnamed address
+const testJourneys = `{
+	"journeys": [{
+		"type": "best",
+		"departure_date_time": "20170629T090000",
+		"arrival_date_time": "20170629T093000",
+		"duration": 1800,
+		"nb_transfers": 0,
+		"sections": [{
+			"id": "section:1",
+			"type": "street_network",
+			"mode": "walking",
+			"from": {"id": "2.3691;48.8397", "name": "10 Rue de Bercy", "embedded_type": "address"},
+			"to": {"id": "stop_point:1", "name": "Bastille", "embedded_type": "stop_point"},
+			"departure_date_time": "20170629T090000",
+			"arrival_date_time": "20170629T090600",
+			"duration": 360
+		}, {
+			"id": "section:2",
+			"type": "waiting",
+			"departure_date_time": "20170629T090600",
+			"arrival_date_time": "20170629T091000",
+			"duration": 240
+		}, {
+			"id": "section:3",
+			"type": "public_transport",
+			"display_informations": {"code": "1", "commercial_mode": "Metro", "physical_mode": "Métro", "direction": "Château de Vincennes", "color": "FFCE00", "text_color": "000000"},
+			"from": {"id": "stop_point:1", "name": "Bastille", "embedded_type": "stop_point"},
+			"to": {"id": "stop_point:2", "name": "Nation", "embedded_type": "stop_point"},
+			"departure_date_time": "20170629T091000",
+			"arrival_date_time": "20170629T092000",
+			"duration": 600
+		}, {
+			"id": "section:4",
+			"type": "street_network",
+			"mode": "walking",
+			"from": {"id": "stop_point:2", "name": "Nation", "embedded_type": "stop_point"},
+			"to": {"id": "2.3958;48.8483", "name": "", "embedded_type": "address"},
+			"departure_date_time": "20170629T092000",
+			"arrival_date_time": "20170629T093000",
+			"duration": 600
+		}]
+	}]
+}`
+
+// testPlaces are a stop area & an address, both with their coordinates
+const testPlaces = `{
+	"places": [{
+		"id": "stop_area:RAT:SA:BASTI",
+		"name": "Bastille (Paris)",
+		"quality": 90,
+		"embedded_type": "stop_area",
+		"stop_area": {"id": "stop_area:RAT:SA:BASTI", "name": "Bastille", "coord": {"lat": "48.853", "lon": "2.3692"}, "timezone": "Europe/Paris"}
+	}, {
+		"id": "2.3691;48.8397",
+		"name": "10 Rue de Bercy (Paris)",
+		"quality": 80,
+		"embedded_type": "address",
+		"address": {"id": "2.3691;48.8397", "name": "Rue de Bercy", "house_number": 10, "coord": {"lat": "48.8397", "lon": "2.3691"}}
+	}]
+}`
+
+// update rewrites the golden files with the current outputs, to be run as "go test ./pretty -update"
+var update = flag.Bool("update", false, "update the golden files")
+
+// golden compares got with the content of the golden file testdata/name
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("error while updating %s: %v", path, err)
+		}
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error while reading %s: %v", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s: got\n%s\nwant\n%s", name, got, want)
+	}
+}
+
+// unmarshal decodes the fixture into v
+func unmarshal(t *testing.T, fixture string, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(fixture), v); err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+}
+
+// TestMarkdownRenderer checks the Markdown written, escaped & with the text of HTML messages
+func TestMarkdownRenderer(t *testing.T) {
+	var (
+		jr navitia.JourneyResults
+		pr navitia.PlacesResults
+		cr navitia.ConnectionsResults
+		ds []types.Disruption
+	)
+	unmarshal(t, testJourneys, &jr)
+	unmarshal(t, testPlaces, &pr)
+	unmarshal(t, testDepartures, &cr)
+	unmarshal(t, testDisruptions, &ds)
+	r := MarkdownRenderer{Locale: LocaleFrench}
+
+	var buf bytes.Buffer
+	if err := r.WriteJourneys(&jr, &buf); err != nil {
+		t.Fatalf("error in WriteJourneys: %v", err)
+	}
+	golden(t, "journeys.md", buf.Bytes())
+
+	buf.Reset()
+	if err := r.WritePlaces(&pr, &buf); err != nil {
+		t.Fatalf("error in WritePlaces: %v", err)
+	}
+	golden(t, "places.md", buf.Bytes())
+
+	buf.Reset()
+	if err := r.WriteDepartures(&cr, &buf); err != nil {
+		t.Fatalf("error in WriteDepartures: %v", err)
+	}
+	golden(t, "departures.md", buf.Bytes())
+
+	buf.Reset()
+	if err := r.WriteDisruptions(ds, &buf); err != nil {
+		t.Fatalf("error in WriteDisruptions: %v", err)
+	}
+	golden(t, "disruptions.md", buf.Bytes())
+}
+
+// TestHTMLRenderer checks the HTML written, with its styles inlined
+func TestHTMLRenderer(t *testing.T) {
+	var (
+		jr navitia.JourneyResults
+		pr navitia.PlacesResults
+		cr navitia.ConnectionsResults
+	)
+	unmarshal(t, testJourneys, &jr)
+	unmarshal(t, testPlaces, &pr)
+	unmarshal(t, testDepartures, &cr)
+	r := HTMLRenderer{}
+
+	var buf bytes.Buffer
+	if err := r.WriteJourneys(&jr, &buf); err != nil {
+		t.Fatalf("error in WriteJourneys: %v", err)
+	}
+	golden(t, "journeys.html", buf.Bytes())
+
+	buf.Reset()
+	if err := r.WritePlaces(&pr, &buf); err != nil {
+		t.Fatalf("error in WritePlaces: %v", err)
+	}
+	golden(t, "places.html", buf.Bytes())
+
+	buf.Reset()
+	if err := r.WriteDepartures(&cr, &buf); err != nil {
+		t.Fatalf("error in WriteDepartures: %v", err)
+	}
+	golden(t, "departures.html", buf.Bytes())
+}
+
+// TestJSONRenderer checks the JSON written, with its times in the given time zone
+func TestJSONRenderer(t *testing.T) {
+	var (
+		jr navitia.JourneyResults
+		pr navitia.PlacesResults
+		ds []types.Disruption
+	)
+	unmarshal(t, testJourneys, &jr)
+	unmarshal(t, testPlaces, &pr)
+	unmarshal(t, testDisruptions, &ds)
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	r := JSONRenderer{Indent: "\t", Location: paris}
+
+	var buf bytes.Buffer
+	if err := r.WriteJourneys(&jr, &buf); err != nil {
+		t.Fatalf("error in WriteJourneys: %v", err)
+	}
+	golden(t, "journeys.json", buf.Bytes())
+
+	buf.Reset()
+	if err := r.WritePlaces(&pr, &buf); err != nil {
+		t.Fatalf("error in WritePlaces: %v", err)
+	}
+	golden(t, "places.json", buf.Bytes())
+
+	buf.Reset()
+	if err := r.WriteDisruptions(ds, &buf); err != nil {
+		t.Fatalf("error in WriteDisruptions: %v", err)
+	}
+	golden(t, "disruptions.json", buf.Bytes())
+}
@@ -0,0 +1,309 @@
+package pretty
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/govitia/navitia"
+	"github.com/govitia/navitia/internal/describe"
+	"github.com/govitia/navitia/types"
+)
+
+// SchemaVersion is the version of the normalized JSON schema written by JSONRenderer.
+// It is incremented whenever a field is removed or changes meaning, adding fields doesn't change it.
+const SchemaVersion = 1
+
+// A Journey is the normalized form of a types.Journey
+type Journey struct {
+	Type      string    `json:"type,omitempty"`
+	Departure time.Time `json:"departure"`
+	Arrival   time.Time `json:"arrival"`
+	Duration  int64     `json:"duration"` // In seconds
+	Transfers uint      `json:"transfers"`
+	Sections  []Section `json:"sections"`
+}
+
+// A Section is the normalized form of a types.Section
+type Section struct {
+	Type        string    `json:"type"`
+	Mode        string    `json:"mode,omitempty"` // For street network sections, such as types.ModeWalking
+	Line        *Line     `json:"line,omitempty"` // For public transport sections
+	From        Place     `json:"from"`
+	To          Place     `json:"to"`
+	Departure   time.Time `json:"departure"`
+	Arrival     time.Time `json:"arrival"`
+	Duration    int64     `json:"duration"`              // In seconds
+	Disruptions []string  `json:"disruptions,omitempty"` // IDs of the disruptions impacting the section
+}
+
+// A Line is the normalized form of the display informations of a public transport section or departure
+type Line struct {
+	Code           string `json:"code"`
+	Name           string `json:"name,omitempty"`
+	Network        string `json:"network,omitempty"`
+	CommercialMode string `json:"commercial_mode,omitempty"`
+	PhysicalMode   string `json:"physical_mode,omitempty"`
+	Direction      string `json:"direction,omitempty"`
+	Headsign       string `json:"headsign,omitempty"`
+	Color          string `json:"color,omitempty"`      // As "#rrggbb"
+	TextColor      string `json:"text_color,omitempty"` // As "#rrggbb"
+}
+
+// Badge returns the text of the badge of the line: its code, or else its name
+func (l Line) Badge() string {
+	if l.Code != "" {
+		return l.Code
+	}
+	return l.Name
+}
+
+// A Place is the normalized form of a types.Container or types.StopPoint
+type Place struct {
+	ID       string       `json:"id"`
+	Name     string       `json:"name"`
+	Type     string       `json:"type,omitempty"`
+	Quality  int          `json:"quality,omitempty"`
+	Coord    *Coordinates `json:"coord,omitempty"`
+	Platform string       `json:"platform,omitempty"`
+}
+
+// Coordinates are the normalized form of types.Coordinates
+type Coordinates struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+}
+
+// A Departure is the normalized form of a navitia.Connection
+type Departure struct {
+	Stop          Place      `json:"stop"`
+	Line          Line       `json:"line"`
+	Departure     time.Time  `json:"departure"`
+	BaseDeparture *time.Time `json:"base_departure,omitempty"`
+	Delay         int64      `json:"delay"` // In seconds, negative if early
+	Realtime      bool       `json:"realtime"`
+}
+
+// A Disruption is the normalized form of a types.Disruption
+type Disruption struct {
+	ID       string    `json:"id"`
+	Status   string    `json:"status,omitempty"`
+	Severity string    `json:"severity,omitempty"`
+	Effect   string    `json:"effect,omitempty"`
+	Priority *int      `json:"priority,omitempty"`
+	Color    string    `json:"color,omitempty"` // As "#rrggbb"
+	Cause    string    `json:"cause,omitempty"`
+	Category string    `json:"category,omitempty"`
+	Periods  []Period  `json:"periods"`
+	Messages []Message `json:"messages"`
+	Impacted []Place   `json:"impacted"`
+}
+
+// A Period is the normalized form of a types.Period
+type Period struct {
+	Begin time.Time `json:"begin"`
+	End   time.Time `json:"end"`
+}
+
+// A Message is the normalized form of a types.Message
+type Message struct {
+	Text    string `json:"text"`
+	Channel string `json:"channel,omitempty"`
+	HTML    bool   `json:"html,omitempty"`
+}
+
+// The normalizing functions below take the time zone of Navitia's wall-clock times as loc, nil meaning UTC
+
+// newJourneys normalizes the journeys of jr
+func newJourneys(jr *navitia.JourneyResults, loc *time.Location) []Journey {
+	journeys := make([]Journey, len(jr.Journeys))
+	for i := range jr.Journeys {
+		journeys[i] = newJourney(&jr.Journeys[i], loc)
+	}
+	return journeys
+}
+
+// newJourney normalizes j, skipping its waiting sections
+func newJourney(j *types.Journey, loc *time.Location) Journey {
+	journey := Journey{
+		Type:      string(j.Type),
+		Departure: describe.Time(j.Departure, loc),
+		Arrival:   describe.Time(j.Arrival, loc),
+		Duration:  seconds(j.Duration),
+		Transfers: j.Transfers,
+		Sections:  []Section{},
+	}
+
+	for i := range j.Sections {
+		s := &j.Sections[i]
+		if s.Type == types.SectionWaiting {
+			continue
+		}
+		journey.Sections = append(journey.Sections, newSection(s, loc))
+	}
+
+	return journey
+}
+
+// newSection normalizes s
+func newSection(s *types.Section, loc *time.Location) Section {
+	section := Section{
+		Type:      string(s.Type),
+		Mode:      s.Mode,
+		From:      newPlace(&s.From),
+		To:        newPlace(&s.To),
+		Departure: describe.Time(s.Departure, loc),
+		Arrival:   describe.Time(s.Arrival, loc),
+		Duration:  seconds(s.Duration),
+	}
+
+	if s.Type == types.SectionPublicTransport {
+		line := newLine(s.Display)
+		section.Line = &line
+	}
+
+	for _, d := range s.Disruptions {
+		section.Disruptions = append(section.Disruptions, string(d.ID))
+	}
+
+	return section
+}
+
+// newLine normalizes the display informations d
+func newLine(d types.Display) Line {
+	return Line{
+		Code:           d.Code,
+		Name:           d.Label,
+		Network:        d.Network,
+		CommercialMode: string(d.CommercialMode),
+		PhysicalMode:   string(d.PhysicalMode),
+		Direction:      d.Direction,
+		Headsign:       d.Headsign,
+		Color:          types.HexColor(d.Color),
+		TextColor:      types.HexColor(d.TextColor),
+	}
+}
+
+// newPlaces normalizes the places of pr
+func newPlaces(pr *navitia.PlacesResults) []Place {
+	places := make([]Place, len(pr.Places))
+	for i := range pr.Places {
+		places[i] = newPlace(&pr.Places[i])
+	}
+	return places
+}
+
+// newPlace normalizes c, with its coordinates if they are known
+func newPlace(c *types.Container) Place {
+	place := Place{
+		ID:      string(c.ID),
+		Name:    c.Name,
+		Type:    c.EmbeddedType,
+		Quality: c.Quality,
+	}
+
+	if coord, ok := c.Coord(); ok {
+		place.Coord = &Coordinates{Latitude: coord.Latitude, Longitude: coord.Longitude}
+	}
+
+	return place
+}
+
+// newDepartures normalizes the connections of cr
+func newDepartures(cr *navitia.ConnectionsResults, loc *time.Location) []Departure {
+	departures := make([]Departure, len(cr.Connections))
+	for i, c := range cr.Connections {
+		departures[i] = Departure{
+			Stop: Place{
+				ID:       string(c.StopPoint.ID),
+				Name:     c.StopPoint.Name,
+				Type:     types.EmbeddedStopPoint,
+				Platform: c.StopPoint.PlatformCode,
+			},
+			Line:      newLine(c.Display),
+			Departure: describe.Time(c.StopDateTime.Departure, loc),
+			Delay:     seconds(c.Delay()),
+			Realtime:  c.IsRealtime(),
+		}
+
+		if base := describe.Time(c.StopDateTime.BaseDeparture, loc); !base.IsZero() {
+			departures[i].BaseDeparture = &base
+		}
+	}
+	return departures
+}
+
+// newDisruptions normalizes ds
+func newDisruptions(ds []types.Disruption, loc *time.Location) []Disruption {
+	disruptions := make([]Disruption, len(ds))
+	for i := range ds {
+		disruptions[i] = newDisruption(&ds[i], loc)
+	}
+	return disruptions
+}
+
+// newDisruption normalizes d
+func newDisruption(d *types.Disruption, loc *time.Location) Disruption {
+	disruption := Disruption{
+		ID:       string(d.ID),
+		Status:   d.Status,
+		Severity: d.Severity.Name,
+		Effect:   string(d.Severity.Effect),
+		Priority: d.Severity.Priority,
+		Color:    types.HexColor(d.Severity.Color),
+		Cause:    d.Cause,
+		Category: d.Category,
+		Periods:  make([]Period, len(d.Periods)),
+		Messages: make([]Message, len(d.Messages)),
+		Impacted: make([]Place, len(d.Impacted)),
+	}
+
+	for i, p := range d.Periods {
+		disruption.Periods[i] = Period{Begin: describe.Time(p.Begin, loc), End: describe.Time(p.End, loc)}
+	}
+
+	for i, m := range d.Messages {
+		disruption.Messages[i] = Message{Text: m.Text, HTML: m.IsHTML()}
+		if m.Channel != nil {
+			disruption.Messages[i].Channel = m.Channel.Name
+		}
+	}
+
+	for i := range d.Impacted {
+		disruption.Impacted[i] = newPlace(&d.Impacted[i].Object)
+	}
+
+	return disruption
+}
+
+// text returns the first plain text message of the disruption, or else the text of its first message stripped of HTML
+func (d Disruption) text() string {
+	for _, m := range d.Messages {
+		if !m.HTML {
+			return m.Text
+		}
+	}
+	if len(d.Messages) != 0 {
+		return htmlText(d.Messages[0].Text)
+	}
+	return ""
+}
+
+// htmlTag matches an HTML tag or comment
+var htmlTag = regexp.MustCompile(`<!--.*?-->|<[^>]*>`)
+
+// htmlText returns the text of an HTML fragment: its tags are removed, its entities unescaped and its spaces collapsed
+func htmlText(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(htmlTag.ReplaceAllString(s, " "))), " ")
+}
+
+// seconds returns d in whole seconds
+func seconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
+
+// duration returns the duration of the given seconds
+func duration(seconds int64) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
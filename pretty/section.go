@@ -42,7 +42,12 @@ type SectionConf struct {
 	Duration *color.Color
 	From     *color.Color
 	To       *color.Color
-	Emoji    bool
+
+	// Emoji, if true, represents the modes by emoji, otherwise by their names
+	Emoji bool
+
+	// Locale of the labels, LocaleEnglish if left empty
+	Locale Locale
 }
 
 // DefaultSectionConf holds a default, quite good configuration
@@ -51,6 +56,29 @@ var DefaultSectionConf = SectionConf{
 	Duration: color.New(color.FgMagenta),
 	From:     color.New(color.FgBlue),
 	To:       color.New(color.FgBlue),
+	Emoji:    true,
+}
+
+// WithLocale returns a copy of the configuration using the given locale
+func (conf SectionConf) WithLocale(l Locale) SectionConf {
+	conf.Locale = l
+	return conf
+}
+
+// mode returns the representation of the mode of the section
+func (conf SectionConf) mode(s *types.Section) string {
+	switch {
+	case s.Mode != "" && conf.Emoji:
+		return modeEmoji[s.Mode]
+	case s.Mode != "":
+		return conf.Locale.Mode(s.Mode)
+	case s.Display.PhysicalMode != "" && conf.Emoji:
+		return modeEmoji[string(s.Display.PhysicalMode)] + s.Display.Label
+	case s.Display.PhysicalMode != "":
+		// The name of the physical mode is already localized by navitia
+		return string(s.Display.PhysicalMode) + " " + s.Display.Label
+	}
+	return ""
 }
 
 // PrettyWrite writes a pretty-printed types.Section to out
@@ -60,20 +88,11 @@ func (conf SectionConf) PrettyWrite(s *types.Section, out io.Writer) error {
 		return nil
 	}
 
-	var middle string
-
-	switch {
-	case s.Mode != "":
-		middle = modeEmoji[s.Mode]
-	case s.Display.PhysicalMode != "":
-		middle = modeEmoji[string(s.Display.PhysicalMode)] + s.Display.Label
-	}
-
 	const msgFmt = "\t%s (%s)\t%s➡️%s\n"
 	msg := fmt.Sprintf(
 		msgFmt,
-		conf.Mode.Sprint(middle),
-		conf.Duration.Sprint(s.Duration.String()),
+		conf.Mode.Sprint(conf.mode(s)),
+		conf.Duration.Sprint(conf.Locale.Duration(s.Duration)),
 		conf.From.Sprint(s.From.Name),
 		conf.To.Sprint(s.To.Name),
 	)
@@ -0,0 +1,154 @@
+package pretty
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A document is a titled list of tables, laid out by MarkdownRenderer & HTMLRenderer
+type document struct {
+	Lang   string
+	Title  string
+	Tables []table
+}
+
+// A table holds results, with an optional title
+type table struct {
+	Title   string
+	Headers []string
+	Rows    [][]cell
+}
+
+// A cell of a table holds a text, preceded by the badge of a line if given
+type cell struct {
+	Line *Line
+	Text string
+}
+
+// journeysDocument lays out journeys, one table per journey
+func journeysDocument(l Locale, journeys []Journey) document {
+	labels := l.labels()
+	doc := document{Lang: l.or().Tag.String(), Title: labels.Journeys}
+
+	for i, j := range journeys {
+		t := table{
+			Title: fmt.Sprintf("%d. %s → %s (%s)",
+				i+1,
+				l.Time(j.Departure),
+				l.Time(j.Arrival),
+				l.Duration(duration(j.Duration)),
+			),
+			Headers: []string{labels.Mode, labels.From, labels.To, labels.Departure, labels.Arrival, labels.Duration},
+		}
+
+		for _, s := range j.Sections {
+			mode := cell{Text: l.Mode(s.Mode)}
+			switch {
+			case s.Line != nil:
+				mode = cell{Line: s.Line, Text: s.Line.PhysicalMode}
+			case s.Mode == "":
+				mode = cell{Text: l.Mode(s.Type)}
+			}
+
+			t.Rows = append(t.Rows, []cell{
+				mode,
+				{Text: s.From.Name},
+				{Text: s.To.Name},
+				{Text: l.Time(s.Departure)},
+				{Text: l.Time(s.Arrival)},
+				{Text: l.Duration(duration(s.Duration))},
+			})
+		}
+
+		doc.Tables = append(doc.Tables, t)
+	}
+
+	return doc
+}
+
+// placesDocument lays out places in a single table
+func placesDocument(l Locale, places []Place) document {
+	labels := l.labels()
+	t := table{
+		Title:   l.Count(len(places)),
+		Headers: []string{"#", labels.Type, labels.Name},
+	}
+
+	for i, p := range places {
+		t.Rows = append(t.Rows, []cell{
+			{Text: strconv.Itoa(i + 1)},
+			{Text: l.PlaceType(p.Type)},
+			{Text: p.Name},
+		})
+	}
+
+	return document{Lang: l.or().Tag.String(), Title: labels.Places, Tables: []table{t}}
+}
+
+// departuresDocument lays out departures in a single table
+func departuresDocument(l Locale, departures []Departure) document {
+	labels := l.labels()
+	t := table{
+		Headers: []string{labels.Departure, labels.Line, labels.Direction, labels.Stop, labels.Platform, labels.Delay},
+	}
+
+	for _, d := range departures {
+		line := d.Line
+
+		direction := line.Direction
+		if direction == "" {
+			direction = line.Headsign
+		}
+
+		var delay string
+		switch {
+		case d.Delay > 0:
+			delay = "+" + l.Duration(duration(d.Delay))
+		case d.Delay < 0:
+			delay = "-" + l.Duration(duration(-d.Delay))
+		}
+
+		t.Rows = append(t.Rows, []cell{
+			{Text: l.Time(d.Departure)},
+			{Line: &line},
+			{Text: direction},
+			{Text: d.Stop.Name},
+			{Text: d.Stop.Platform},
+			{Text: delay},
+		})
+	}
+
+	return document{Lang: l.or().Tag.String(), Title: labels.Departures, Tables: []table{t}}
+}
+
+// disruptionsDocument lays out disruptions in a single table
+func disruptionsDocument(l Locale, disruptions []Disruption) document {
+	labels := l.labels()
+	t := table{
+		Headers: []string{labels.Severity, labels.Effect, labels.Period, labels.Impacted, labels.Message},
+	}
+
+	for _, d := range disruptions {
+		periods := make([]string, len(d.Periods))
+		for i, p := range d.Periods {
+			periods[i] = p.Begin.Format("2006-01-02") + " " + l.Time(p.Begin) + " → " +
+				p.End.Format("2006-01-02") + " " + l.Time(p.End)
+		}
+
+		impacted := make([]string, len(d.Impacted))
+		for i, p := range d.Impacted {
+			impacted[i] = p.Name
+		}
+
+		t.Rows = append(t.Rows, []cell{
+			{Text: d.Severity},
+			{Text: d.Effect},
+			{Text: strings.Join(periods, ", ")},
+			{Text: strings.Join(impacted, ", ")},
+			{Text: d.text()},
+		})
+	}
+
+	return document{Lang: l.or().Tag.String(), Title: labels.Disruptions, Tables: []table{t}}
+}
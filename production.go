@@ -0,0 +1,92 @@
+package navitia
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/govitia/navitia/types"
+)
+
+// A ProductionPolicy defines what a Scope does with request dates outside of the production period of its region.
+type ProductionPolicy int
+
+// ProductionXXX are the known production policies
+const (
+	// ProductionIgnore sends the requests as-is, leaving the server to reject them. This is the default.
+	ProductionIgnore ProductionPolicy = iota
+
+	// ProductionReject rejects requests whose dates are outside of the production period with an OutOfProductionError, without querying the server.
+	ProductionReject
+
+	// ProductionClamp moves the dates outside of the production period to its nearest bound.
+	ProductionClamp
+)
+
+// CheckProduction loads the metadata of the scope's region, and makes the scope check the dates of the following
+// journeys, departures, arrivals and vehicle journeys requests against its production period, following the given policy.
+//
+// As it modifies the scope, it must not be called while the scope is in use.
+func (scope *Scope) CheckProduction(ctx context.Context, policy ProductionPolicy) error {
+	if policy == ProductionIgnore {
+		scope.policy = policy
+		return nil
+	}
+
+	res, err := scope.session.RegionByID(ctx, RegionRequest{}, scope.region)
+	if err != nil {
+		return errors.Wrapf(err, "error while retrieving region %s", scope.region)
+	}
+	if len(res.Regions) == 0 {
+		return errors.Errorf("region %s not found", scope.region)
+	}
+	r := res.Regions[0]
+	if r.ProductionStart.IsZero() || r.ProductionEnd.IsZero() {
+		return errors.Errorf("region %s has no production period", scope.region)
+	}
+
+	scope.productionStart = r.ProductionStart
+	scope.productionEnd = r.ProductionEnd
+	scope.policy = policy
+	return nil
+}
+
+// checkDate checks a date given for the param parameter against the production period of the region, following the scope's policy.
+// It returns the date to use, which is only modified when clamping.
+//
+// As Navitia dates are wall-clock times of the region, the comparison is done on the wall-clock time of the date given,
+// whatever its location.
+func (scope *Scope) checkDate(param string, date time.Time) (time.Time, error) {
+	if scope.policy == ProductionIgnore || date.IsZero() {
+		return date, nil
+	}
+
+	// The production period includes its last day
+	start := scope.productionStart
+	end := scope.productionEnd.AddDate(0, 0, 1).Add(-time.Second)
+
+	wall := types.InLocation(date, time.UTC)
+	var bound time.Time
+	switch {
+	case wall.Before(start):
+		bound = start
+	case wall.After(end):
+		bound = end
+	default:
+		return date, nil
+	}
+
+	if scope.policy == ProductionReject {
+		return date, OutOfProductionError{
+			Region: scope.region,
+			Param:  param,
+			Date:   date,
+			Start:  scope.productionStart,
+			End:    scope.productionEnd,
+		}
+	}
+
+	// Clamp it, keeping the location of the date given
+	return time.Date(bound.Year(), bound.Month(), bound.Day(), bound.Hour(), bound.Minute(), bound.Second(), 0, date.Location()), nil
+}
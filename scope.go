@@ -1,6 +1,8 @@
 package navitia
 
 import (
+	"time"
+
 	"golang.org/x/net/context"
 
 	"github.com/govitia/navitia/types"
@@ -12,61 +14,81 @@ import (
 type Scope struct {
 	region  types.ID
 	session *Session
+
+	// Production period checks, see CheckProduction
+	policy          ProductionPolicy
+	productionStart time.Time
+	productionEnd   time.Time
+}
+
+// connections checks the request's date before calling the session's connections
+func (scope *Scope) connections(ctx context.Context, url string, req ConnectionsRequest) (*ConnectionsResults, error) {
+	var err error
+	req.From, err = scope.checkDate("datetime", req.From)
+	if err != nil {
+		return nil, err
+	}
+
+	return scope.session.connections(ctx, url, req)
 }
 
 // ArrivalsSA requests the arrivals for a given StopArea in a given region.
+//
+// Deprecated: use Scope.Arrivals, which accepts any object.
 func (scope *Scope) ArrivalsSA(ctx context.Context, req ConnectionsRequest, resource types.ID) (*ConnectionsResults, error) {
-	// Create the URL
-	scopeURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/stop_areas/" + string(resource) + "/" + arrivalsEndpoint
-
-	return scope.session.connections(ctx, scopeURL, req)
+	return scope.Arrivals(ctx, req, resource)
 }
 
 // ArrivalsSP requests the arrivals for a given StopPoint in a given region.
+//
+// Deprecated: use Scope.Arrivals, which accepts any object.
 func (scope *Scope) ArrivalsSP(ctx context.Context, req ConnectionsRequest, resource types.ID) (*ConnectionsResults, error) {
-	// Create the URL
-	scopeURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/stop_points/" + string(resource) + "/" + arrivalsEndpoint
-
-	return scope.session.connections(ctx, scopeURL, req)
+	return scope.Arrivals(ctx, req, resource)
 }
 
 // ArrivalsC requests the arrivals from a point described by coordinates.
+//
+// Deprecated: use Scope.Arrivals with coords.ID(), scoped to the region or to the coordinates themselves.
 func (s *Session) ArrivalsC(ctx context.Context, req ConnectionsRequest, coords types.Coordinates) (*ConnectionsResults, error) {
-	// Create the URL
-	coordsQ := string(coords.ID())
-	scopeURL := s.APIURL + "/coverage/" + coordsQ + "/coords/" + coordsQ + "/" + arrivalsEndpoint
+	return s.Scope(coords.ID()).Arrivals(ctx, req, coords.ID())
+}
 
-	return s.connections(ctx, scopeURL, req)
+// Departures requests the departures from the given object of the region: a stop area, stop point, line, route, network,
+// commercial or physical mode, company, or coordinates (as given by types.Coordinates.ID).
+func (scope *Scope) Departures(ctx context.Context, req ConnectionsRequest, object types.ID) (*ConnectionsResults, error) {
+	return scope.objectConnections(ctx, req, object, departuresEndpoint)
 }
 
-// Departures computes a list of Departures according to the parameters given in a specific scope
-func (scope *Scope) Departures(ctx context.Context, req DeparturesRequest) (*DeparturesResults, error) {
-	// there is a special case for departures stop areas, it needs to be added before any parameters
-	filterByVJ := ""
-	if req.StopArea != "" {
-		filterByVJ = "stop_areas/" + req.StopArea
+// Arrivals requests the arrivals to the given object of the region, which may be any object accepted by Departures.
+func (scope *Scope) Arrivals(ctx context.Context, req ConnectionsRequest, object types.ID) (*ConnectionsResults, error) {
+	return scope.objectConnections(ctx, req, object, arrivalsEndpoint)
+}
+
+// objectConnections requests the departures or arrivals, depending on the endpoint, of the given object
+func (scope *Scope) objectConnections(ctx context.Context, req ConnectionsRequest, object types.ID, endpoint string) (*ConnectionsResults, error) {
+	path, err := objectPath(object)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create the URL
-	reqURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + filterByVJ + "/" + departuresEndpoint
+	scopeURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + path + "/" + endpoint
 
-	return scope.session.departures(ctx, reqURL, req)
+	return scope.connections(ctx, scopeURL, req)
 }
 
 // DeparturesSA requests the departures for a given StopArea
+//
+// Deprecated: use Scope.Departures, which accepts any object.
 func (scope *Scope) DeparturesSA(ctx context.Context, req ConnectionsRequest, resource types.ID) (*ConnectionsResults, error) {
-	// Create the URL
-	scopeURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/stop_areas/" + string(resource) + "/" + departuresEndpoint
-
-	return scope.session.connections(ctx, scopeURL, req)
+	return scope.Departures(ctx, req, resource)
 }
 
 // DeparturesSP requests the departures for a given StopPoint
+//
+// Deprecated: use Scope.Departures, which accepts any object.
 func (scope *Scope) DeparturesSP(ctx context.Context, req ConnectionsRequest, resource types.ID) (*ConnectionsResults, error) {
-	// Create the URL
-	scopeURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/stop_points/" + string(resource) + "/" + departuresEndpoint
-
-	return scope.session.connections(ctx, scopeURL, req)
+	return scope.Departures(ctx, req, resource)
 }
 
 // Journeys computes a list of journeys according to the parameters given in a specific scope
@@ -74,6 +96,13 @@ func (scope *Scope) Journeys(ctx context.Context, req JourneyRequest) (*JourneyR
 	// Create the URL
 	reqURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + journeysEndpoint
 
+	// Check the date
+	var err error
+	req.Date, err = scope.checkDate("datetime", req.Date)
+	if err != nil {
+		return nil, err
+	}
+
 	// Call
 	return scope.session.journeys(ctx, reqURL, req)
 }
@@ -88,16 +117,47 @@ func (scope *Scope) Places(ctx context.Context, params PlacesRequest) (*PlacesRe
 	return scope.session.places(ctx, reqURL, params)
 }
 
-// VehicleJourneys computes a list of VehicleJourneys according to the parameters given in a specific scope
+// vehicleJourneys checks the request's period before calling the session's vehicleJourneys
+func (scope *Scope) vehicleJourneys(ctx context.Context, url string, req VehicleJourneyRequest) (*VehicleJourneyResults, error) {
+	var err error
+	req.Since, err = scope.checkDate("since", req.Since)
+	if err != nil {
+		return nil, err
+	}
+	req.Until, err = scope.checkDate("until", req.Until)
+	if err != nil {
+		return nil, err
+	}
+
+	return scope.session.vehicleJourneys(ctx, url, req)
+}
+
+// VehicleJourneys lists the vehicle journeys of the region according to the parameters given
 func (scope *Scope) VehicleJourneys(ctx context.Context, req VehicleJourneyRequest) (*VehicleJourneyResults, error) {
-	// there is a special case for vehicle journey ID, it needs to be added before any parameters
-	filterByVJ := ""
-	if req.ID != "" {
-		filterByVJ = "/" + string(req.ID)
+	// Create the URL
+	reqURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + vehicleJourneysEndpoint
+
+	return scope.vehicleJourneys(ctx, reqURL, req)
+}
+
+// VehicleJourney retrieves the vehicle journey with the given ID, along with its stop times
+func (scope *Scope) VehicleJourney(ctx context.Context, req VehicleJourneyRequest, id types.ID) (*VehicleJourneyResults, error) {
+	// Create the URL
+	reqURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + vehicleJourneysEndpoint + "/" + string(id)
+
+	return scope.vehicleJourneys(ctx, reqURL, req)
+}
+
+// ObjectVehicleJourneys lists the vehicle journeys serving the given object of the region, such as a line, a route or a stop area.
+// It accepts the same objects as Departures.
+func (scope *Scope) ObjectVehicleJourneys(ctx context.Context, req VehicleJourneyRequest, object types.ID) (*VehicleJourneyResults, error) {
+	path, err := objectPath(object)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create the URL
-	reqURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + vehicleJourneysEndpoint + filterByVJ
+	reqURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + path + "/" + vehicleJourneysEndpoint
 
-	return scope.session.vehicleJourneys(ctx, reqURL, req)
+	return scope.vehicleJourneys(ctx, reqURL, req)
 }
@@ -1,7 +1,13 @@
 package navitia
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/pkg/errors"
 )
 
 func Test_New(t *testing.T) {
@@ -13,3 +19,52 @@ func Test_New(t *testing.T) {
 		t.Fatalf("Error while creating new session: %v", err)
 	}
 }
+
+// TestScope_CheckProduction checks that request dates are rejected or clamped to the production period of the region
+func TestScope_CheckProduction(t *testing.T) {
+	var sent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/coverage/fr-idf":
+			_, _ = w.Write([]byte(`{"regions": [{"id": "fr-idf", "start_production_date": "20170321", "end_production_date": "20170620"}]}`))
+		default:
+			sent = r.URL.Query().Get("datetime")
+			_, _ = w.Write([]byte(`{"journeys": []}`))
+		}
+	}))
+	defer srv.Close()
+
+	s, err := NewCustom("key", srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("error in NewCustom: %v", err)
+	}
+
+	ctx := context.Background()
+	late := JourneyRequest{Date: time.Date(2017, 7, 1, 8, 0, 0, 0, time.UTC)}
+	lastDay := JourneyRequest{Date: time.Date(2017, 6, 20, 23, 0, 0, 0, time.UTC)}
+
+	// Reject
+	scope := s.Scope("fr-idf")
+	if err := scope.CheckProduction(ctx, ProductionReject); err != nil {
+		t.Fatalf("error in CheckProduction: %v", err)
+	}
+	_, err = scope.Journeys(ctx, late)
+	var prodErr OutOfProductionError
+	if !errors.As(err, &prodErr) {
+		t.Fatalf("expected an OutOfProductionError, got %v", err)
+	}
+	if _, err := scope.Journeys(ctx, lastDay); err != nil {
+		t.Errorf("expected the last day of production to be accepted, got %v", err)
+	}
+
+	// Clamp
+	if err := scope.CheckProduction(ctx, ProductionClamp); err != nil {
+		t.Fatalf("error in CheckProduction: %v", err)
+	}
+	if _, err := scope.Journeys(ctx, late); err != nil {
+		t.Fatalf("error in Journeys: %v", err)
+	}
+	if sent != "20170620T235959" {
+		t.Errorf("expected the date to be clamped to the end of production, got %s", sent)
+	}
+}
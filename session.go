@@ -31,8 +31,14 @@ type Session struct {
 	APIKey string
 	APIURL string
 
-	client  *http.Client
-	created time.Time
+	// AutoScope enables automatic scoping of global Journeys and Places requests.
+	// When enabled, the request is sent to the region whose shape contains the journey origin & destination coordinates,
+	// or the places Around coordinates, falling back to the global endpoint when none match.
+	AutoScope bool
+
+	client   *http.Client
+	created  time.Time
+	coverage *coverage
 }
 
 // New creates a new session given an API Key.
@@ -46,42 +52,26 @@ func New(key string) (*Session, error) {
 // NewCustom creates a custom new session given an API key, URL to api base & http client
 func NewCustom(key, url string, client *http.Client) (*Session, error) {
 	return &Session{
-		APIKey:  key,
-		APIURL:  url,
-		created: time.Now(),
-		client:  client,
+		APIKey:   key,
+		APIURL:   url,
+		created:  time.Now(),
+		client:   client,
+		coverage: newCoverage(),
 	}, nil
 }
 
-// departures is the internal function used by Departures & Arrivals functions
+// connections is the internal function used by Departures & Arrivals functions
 func (s *Session) connections(ctx context.Context, url string, req ConnectionsRequest) (*ConnectionsResults, error) {
-	results := &ConnectionsResults{}
+	results := &ConnectionsResults{session: s}
 	err := s.request(ctx, url, req, results)
 	return results, err
 }
 
-// departures is the internal function used by Journeys functions
-func (s *Session) departures(ctx context.Context, url string, req DeparturesRequest) (*DeparturesResults, error) {
-	results := &DeparturesResults{session: s}
-	err := s.request(ctx, url, req, results)
-	return results, err
-}
-
-// Departures computes a list of Departures according to the parameters given
-func (s *Session) Departures(ctx context.Context, req DeparturesRequest) (*DeparturesResults, error) {
-	// Create the URL
-	reqURL := s.APIURL + "/" + departuresEndpoint
-
-	return s.departures(ctx, reqURL, req)
-}
-
 // DeparturesC requests the departures from a point described by coordinates.
+//
+// Deprecated: use Scope.Departures with coords.ID(), scoped to the region or to the coordinates themselves.
 func (s *Session) DeparturesC(ctx context.Context, req ConnectionsRequest, coords types.Coordinates) (*ConnectionsResults, error) {
-	// Create the URL
-	coordsQ := string(coords.ID())
-	scopeURL := s.APIURL + "/coverage/" + coordsQ + "/coords/" + coordsQ + "/" + departuresEndpoint
-
-	return s.connections(ctx, scopeURL, req)
+	return s.Scope(coords.ID()).Departures(ctx, req, coords.ID())
 }
 
 // journeys is the internal function used by Journeys functions
@@ -92,12 +82,28 @@ func (s *Session) journeys(ctx context.Context, url string, req JourneyRequest)
 }
 
 // Journeys computes a list of journeys according to the parameters given
+//
+// If AutoScope is enabled and the origin & destination are coordinates, the request is scoped to the region containing them.
+// If the region can't be located, the global endpoint is used.
 func (s *Session) Journeys(ctx context.Context, req JourneyRequest) (*JourneyResults, error) {
+	var locateErr error
+	if s.AutoScope {
+		var region types.ID
+		region, locateErr = s.locateIDs(ctx, req.From, req.To)
+		if region != "" {
+			return s.Scope(region).Journeys(ctx, req)
+		}
+	}
+
 	// Create the URL
 	reqURL := s.APIURL + "/" + journeysEndpoint
 
 	// Call
-	return s.journeys(ctx, reqURL, req)
+	res, err := s.journeys(ctx, reqURL, req)
+	if err != nil && locateErr != nil {
+		err = errors.Wrapf(err, "error while locating the journey's region (%v), then on the global endpoint", locateErr)
+	}
+	return res, err
 }
 
 // places is the internal function used by Places functions
@@ -115,12 +121,28 @@ func (s *Session) places(ctx context.Context, url string, params PlacesRequest)
 
 // Places searches in all geographical objects using their names, returning a list of corresponding places.
 // It is context aware.
+//
+// If AutoScope is enabled and params.Around is given, the request is scoped to the region containing it.
+// If the region can't be located, the global endpoint is used.
 func (s *Session) Places(ctx context.Context, params PlacesRequest) (*PlacesResults, error) {
+	var locateErr error
+	if s.AutoScope && params.Around != (types.Coordinates{}) {
+		var region types.ID
+		region, locateErr = s.Locate(ctx, params.Around)
+		if region != "" {
+			return s.Scope(region).Places(ctx, params)
+		}
+	}
+
 	// Create the URL
 	reqURL := s.APIURL + "/" + placesEndpoint
 
 	// Call
-	return s.places(ctx, reqURL, params)
+	res, err := s.places(ctx, reqURL, params)
+	if err != nil && locateErr != nil {
+		err = errors.Wrapf(err, "error while locating the places' region (%v), then on the global endpoint", locateErr)
+	}
+	return res, err
 }
 
 func (s *Session) region(ctx context.Context, url string, params RegionRequest) (*RegionResults, error) {
@@ -184,9 +206,6 @@ func (s *Session) requestURL(ctx context.Context, url string, res results) error
 	if err != nil {
 		return errors.Wrap(err, "error while executing request")
 	}
-	if resp.StatusCode != http.StatusOK {
-		return parseRemoteError(resp)
-	}
 
 	// Defer the close
 	defer func() {
@@ -195,6 +214,10 @@ func (s *Session) requestURL(ctx context.Context, url string, res results) error
 		}
 	}()
 
+	if resp.StatusCode != http.StatusOK {
+		return parseRemoteError(resp)
+	}
+
 	// Check for cancellation
 	select {
 	case <-ctx.Done():
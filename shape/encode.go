@@ -0,0 +1,54 @@
+package shape
+
+import (
+	"math"
+	"strings"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/wkt"
+)
+
+// Polyline encodes the line with Google's encoded polyline algorithm, with the given precision in decimal digits.
+// Google Maps uses a precision of 5, while some other services, such as OSRM, may use 6.
+//
+// See https://developers.google.com/maps/documentation/utilities/polylinealgorithm
+func Polyline(ls *geom.LineString, precision int) string {
+	if ls == nil {
+		return ""
+	}
+
+	factor := math.Pow10(precision)
+	var (
+		sb               strings.Builder
+		lastLat, lastLon int64
+	)
+	for i := 0; i < ls.NumCoords(); i++ {
+		c := ls.Coord(i)
+		lat, lon := int64(math.Round(c.Y()*factor)), int64(math.Round(c.X()*factor))
+		writePolylineValue(&sb, lat-lastLat)
+		writePolylineValue(&sb, lon-lastLon)
+		lastLat, lastLon = lat, lon
+	}
+	return sb.String()
+}
+
+// writePolylineValue writes a delta of the polyline encoding
+func writePolylineValue(sb *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		sb.WriteByte(byte(0x20|u&0x1f) + 63)
+		u >>= 5
+	}
+	sb.WriteByte(byte(u) + 63)
+}
+
+// WKT encodes the line as Well-Known Text, such as "LINESTRING (2.37 48.84, 2.38 48.85)"
+func WKT(ls *geom.LineString) (string, error) {
+	if ls == nil {
+		return "LINESTRING EMPTY", nil
+	}
+	return wkt.Marshal(ls)
+}
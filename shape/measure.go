@@ -0,0 +1,50 @@
+package shape
+
+import (
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+
+	"github.com/govitia/navitia/types"
+)
+
+// metersPerDegree is the length of a degree of latitude, in meters
+const metersPerDegree = 111320
+
+// Length returns the length of the line in meters, computed on a spherical approximation of the Earth.
+func Length(ls *geom.LineString) float64 {
+	if ls == nil {
+		return 0
+	}
+
+	var length float64
+	for i := 1; i < ls.NumCoords(); i++ {
+		length += coordinates(ls.Coord(i - 1)).DistanceTo(coordinates(ls.Coord(i)))
+	}
+	return length
+}
+
+// Simplify returns the line simplified with the Ramer-Douglas-Peucker algorithm, dropping the points
+// closer than the tolerance (in meters) to the simplified line. Its ends are kept.
+//
+// The tolerance is converted to degrees as at the equator, so longitudes are simplified a bit less than latitudes away from it,
+// which doesn't matter for display.
+func Simplify(ls *geom.LineString, tolerance float64) *geom.LineString {
+	if ls == nil || ls.NumCoords() <= 2 {
+		return ls
+	}
+
+	stride := ls.Stride()
+	flat := ls.FlatCoords()
+	kept := xy.SimplifyFlatCoords(flat, tolerance/metersPerDegree, stride)
+
+	simplified := make([]float64, 0, len(kept)*stride)
+	for _, i := range kept {
+		simplified = append(simplified, flat[i*stride:(i+1)*stride]...)
+	}
+	return geom.NewLineStringFlat(ls.Layout(), simplified)
+}
+
+// coordinates returns the coordinates of a point of a line
+func coordinates(c geom.Coord) types.Coordinates {
+	return types.Coordinates{Longitude: c.X(), Latitude: c.Y()}
+}
@@ -0,0 +1,64 @@
+// Package shape reconstructs the geometry of journeys from their sections, measures it, simplifies it for display,
+// and encodes it as a Google polyline or WKT. For GeoJSON, see geojson.Journey,
+// which builds on this package.
+//
+// Geometries are go-geom XY line strings, with longitudes on the first dimension and latitudes on the second, as types.Section.Geo.
+package shape
+
+import (
+	"github.com/twpayne/go-geom"
+
+	"github.com/govitia/navitia/types"
+)
+
+// Journey returns the polyline of the whole journey, concatenating the lines of its sections, see Section.
+// It returns nil if none of the sections has a geometry nor coordinates.
+func Journey(j types.Journey) *geom.LineString {
+	var flat []float64
+	for _, s := range j.Sections {
+		ls := Section(s)
+		if ls == nil {
+			continue
+		}
+		coords := ls.FlatCoords()
+		// Don't repeat the point joining two sections
+		if n := len(flat); n != 0 && flat[n-2] == coords[0] && flat[n-1] == coords[1] {
+			coords = coords[2:]
+		}
+		flat = append(flat, coords...)
+	}
+	return lineString(flat)
+}
+
+// Section returns the line of a section: its geometry when given, or else straight lines between its stop points,
+// or else between its ends.
+// It returns nil if there are less than two points to join.
+func Section(s types.Section) *geom.LineString {
+	if s.Geo != nil && s.Geo.NumCoords() >= 2 {
+		return geom.NewLineStringFlat(geom.XY, append([]float64(nil), s.Geo.FlatCoords()...))
+	}
+
+	var flat []float64
+	for _, st := range s.StopTimes {
+		if c := st.StopPoint.Coord; c != (types.Coordinates{}) {
+			flat = append(flat, c.Longitude, c.Latitude)
+		}
+	}
+	if len(flat) < 4 {
+		flat = flat[:0]
+		for _, end := range [...]*types.Container{&s.From, &s.To} {
+			if c, ok := end.Coord(); ok {
+				flat = append(flat, c.Longitude, c.Latitude)
+			}
+		}
+	}
+	return lineString(flat)
+}
+
+// lineString returns the XY line string of the flat coordinates, or nil if there are less than two points
+func lineString(flat []float64) *geom.LineString {
+	if len(flat) < 4 {
+		return nil
+	}
+	return geom.NewLineStringFlat(geom.XY, flat)
+}
@@ -0,0 +1,118 @@
+package shape
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/twpayne/go-geom"
+
+	"github.com/govitia/navitia/types"
+)
+
+// testJourney has a walking section with a geometry, a public transport section without one but with stop points,
+// and a transfer with only its ends
+const testJourney = `{
+	"duration": 1200,
+	"sections": [
+		{
+			"id": "section_0", "type": "street_network", "mode": "walking", "duration": 300,
+			"geojson": {"type": "LineString", "coordinates": [[2.3700, 48.8400], [2.3710, 48.8405], [2.3720, 48.8410]]}
+		},
+		{
+			"id": "section_1", "type": "public_transport", "duration": 600,
+			"display_informations": {"code": "14", "label": "14", "color": "62259D"},
+			"stop_date_times": [
+				{"stop_point": {"id": "stop_point:1", "coord": {"lon": "2.3720", "lat": "48.8410"}}},
+				{"stop_point": {"id": "stop_point:2", "coord": {"lon": "2.3800", "lat": "48.8450"}}},
+				{"stop_point": {"id": "stop_point:3", "coord": {"lon": "2.3900", "lat": "48.8500"}}}
+			]
+		},
+		{
+			"id": "section_2", "type": "transfer", "duration": 300,
+			"from": {"id": "stop_point:3", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:3", "coord": {"lon": "2.3900", "lat": "48.8500"}}},
+			"to": {"id": "stop_point:4", "embedded_type": "stop_point", "stop_point": {"id": "stop_point:4", "coord": {"lon": "2.3910", "lat": "48.8500"}}}
+		}
+	]
+}`
+
+// TestJourney checks the reconstruction of a journey's polyline, and its encodings
+func TestJourney(t *testing.T) {
+	var j types.Journey
+	if err := json.Unmarshal([]byte(testJourney), &j); err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+
+	ls := Journey(j)
+	if ls == nil {
+		t.Fatal("expected a polyline, got nil")
+	}
+	// 3 points of the walk, 2 more stop points & the end of the transfer, without repeating the joints
+	if got := ls.NumCoords(); got != 6 {
+		t.Fatalf("expected 6 points, got %d: %v", got, ls.Coords())
+	}
+
+	if got := Section(types.Section{}); got != nil {
+		t.Errorf("expected no line for an empty section, got %v", got.Coords())
+	}
+}
+
+// TestLength checks the length of a degree of latitude
+func TestLength(t *testing.T) {
+	ls := geom.NewLineStringFlat(geom.XY, []float64{2, 48, 2, 49})
+	if got := Length(ls); math.Abs(got-111195) > 10 {
+		t.Errorf("expected about 111195 meters, got %f", got)
+	}
+	if got := Length(nil); got != 0 {
+		t.Errorf("expected 0 for a nil line, got %f", got)
+	}
+}
+
+// TestSimplify checks that points close to the line are dropped, and others kept
+func TestSimplify(t *testing.T) {
+	ls := geom.NewLineStringFlat(geom.XY, []float64{
+		2.37, 48.84,
+		2.38, 48.84001, // ~1 meter off
+		2.39, 48.84,
+		2.40, 48.85, // ~1 kilometer off
+		2.41, 48.84,
+	})
+
+	got := Simplify(ls, 10)
+	if want := []float64{2.37, 48.84, 2.39, 48.84, 2.40, 48.85, 2.41, 48.84}; !equalFloats(got.FlatCoords(), want) {
+		t.Errorf("got %v, want %v", got.FlatCoords(), want)
+	}
+}
+
+// TestPolyline checks the encoding with Google's example
+func TestPolyline(t *testing.T) {
+	ls := geom.NewLineStringFlat(geom.XY, []float64{-120.2, 38.5, -120.95, 40.7, -126.453, 43.252})
+	if got, want := Polyline(ls, 5), "_p~iF~ps|U_ulLnnqC_mqNvxq`@"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestWKT checks the WKT encoding
+func TestWKT(t *testing.T) {
+	ls := geom.NewLineStringFlat(geom.XY, []float64{2.37, 48.84, 2.38, 48.85})
+	got, err := WKT(ls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "LINESTRING (2.37 48.84, 2.38 48.85)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// equalFloats reports whether both slices hold the same values
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
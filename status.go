@@ -0,0 +1,74 @@
+package navitia
+
+import (
+	"context"
+
+	"github.com/govitia/navitia/types"
+)
+
+const (
+	statusEndpoint    = "status"
+	geoStatusEndpoint = "_geo_status"
+)
+
+// StatusResults holds the status of the Navitia API (jormungandr) and of the regions it serves.
+type StatusResults struct {
+	// Version of the API
+	Version string `json:"jormungandr_version"`
+
+	// Status of each region served
+	Regions []types.RegionStatus `json:"regions"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// RegionStatusResults holds the status of a region
+type RegionStatusResults struct {
+	Status types.RegionStatus `json:"status"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// GeoStatusResults holds the status of the geographical data of a region
+type GeoStatusResults struct {
+	Status types.GeoStatus `json:"geo_status"`
+
+	Logging `json:"-"`
+
+	session *Session
+}
+
+// Status reports the status of the Navitia API, and of each region it serves.
+// It is mostly useful to monitor self-hosted instances.
+func (s *Session) Status(ctx context.Context) (*StatusResults, error) {
+	// Create the URL
+	reqURL := s.APIURL + "/" + statusEndpoint
+
+	results := &StatusResults{session: s}
+	err := s.requestURL(ctx, reqURL, results)
+	return results, err
+}
+
+// Status reports the status of the instance serving the scope's region: kraken & data versions, realtime proxies, etc.
+func (scope *Scope) Status(ctx context.Context) (*RegionStatusResults, error) {
+	// Create the URL
+	reqURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + statusEndpoint
+
+	results := &RegionStatusResults{session: scope.session}
+	err := scope.session.requestURL(ctx, reqURL, results)
+	return results, err
+}
+
+// GeoStatus reports the geographical data loaded for the scope's region: street network sources, amount of POIs, etc.
+func (scope *Scope) GeoStatus(ctx context.Context) (*GeoStatusResults, error) {
+	// Create the URL
+	reqURL := scope.session.APIURL + "/coverage/" + string(scope.region) + "/" + geoStatusEndpoint
+
+	results := &GeoStatusResults{session: scope.session}
+	err := scope.session.requestURL(ctx, reqURL, results)
+	return results, err
+}
@@ -0,0 +1,23 @@
+package navitia
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test_StatusResults_Unmarshal tests unmarshalling for StatusResults, RegionStatusResults & GeoStatusResults.
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+// 	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_StatusResults_Unmarshal(t *testing.T) {
+	t.Run("status", func(t *testing.T) {
+		testUnmarshal(t, testData["status"], reflect.TypeOf(StatusResults{}))
+	})
+	t.Run("region_status", func(t *testing.T) {
+		testUnmarshal(t, testData["regionstatus"], reflect.TypeOf(RegionStatusResults{}))
+	})
+	t.Run("geo_status", func(t *testing.T) {
+		testUnmarshal(t, testData["geostatus"], reflect.TypeOf(GeoStatusResults{}))
+	})
+}
@@ -1,6 +1,50 @@
 package types
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// An ActivePeriod is a period of dates during which a calendar is active.
+// As with Navitia's periods, Begin is included while End is excluded.
 type ActivePeriod struct {
-	Begin string `json:"begin"`
-	End   string `json:"end"`
+	Begin time.Time `json:"begin"`
+	End   time.Time `json:"end"`
+}
+
+// UnmarshalJSON implements json.Unmarshaller for an ActivePeriod
+func (ap *ActivePeriod) UnmarshalJSON(b []byte) error {
+	data := &struct {
+		Begin string `json:"begin"`
+		End   string `json:"end"`
+	}{}
+
+	// Let's create the error generator
+	gen := unmarshalErrorMaker{"ActivePeriod", b}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "Error while unmarshalling ActivePeriod")
+	}
+
+	// Now we process the dates.
+	ap.Begin, err = parseDateTime(data.Begin)
+	if err != nil {
+		return gen.err(err, "Begin", "begin", data.Begin, "parseDateTime failed")
+	}
+	ap.End, err = parseDateTime(data.End)
+	if err != nil {
+		return gen.err(err, "End", "end", data.End, "parseDateTime failed")
+	}
+
+	return nil
+}
+
+// Contains reports whether the date is within the period
+func (ap ActivePeriod) Contains(date time.Time) bool {
+	date = civilDate(date)
+	return !date.Before(civilDate(ap.Begin)) && date.Before(civilDate(ap.End))
 }
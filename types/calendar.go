@@ -1,9 +1,166 @@
 package types
 
+import "time"
+
 // Calendar is returned on vehicle journey message and indicates periodicity informations
 // about transport schedules.
 type Calendar struct {
+	ID   ID     `json:"id"`
+	Name string `json:"name"` // Such as "Semaine" or "Samedi"
+
+	// ValidityPattern gives the dates of the calendar, when returned by the calendars endpoint
+	ValidityPattern ValidityPattern `json:"validity_pattern"`
+
 	ActivePeriods []ActivePeriod `json:"active_periods"`
 	WeekPattern   WeekPattern    `json:"week_pattern"`
 	Exceptions    []Exception    `json:"exceptions"`
 }
+
+// RunsOn reports whether the calendar is active on the given date.
+//
+// The validity pattern is used if present. Otherwise, a date added by an exception is active, a date removed by one isn't, whatever the active periods & week pattern.
+// Otherwise the date is active if it is within one of the active periods, on one of the days of the week pattern.
+//
+// As with Navitia's dates, only the wall-clock date of the given time is taken into account, whatever its location.
+func (c Calendar) RunsOn(date time.Time) bool {
+	if !c.ValidityPattern.IsZero() {
+		return c.ValidityPattern.RunsOn(date)
+	}
+
+	date = civilDate(date)
+	for _, e := range c.Exceptions {
+		if civilDate(e.Date).Equal(date) {
+			switch e.Type {
+			case ExceptionAdd:
+				return true
+			case ExceptionRemove:
+				return false
+			}
+		}
+	}
+
+	if !c.WeekPattern.Has(date.Weekday()) {
+		return false
+	}
+	for _, ap := range c.ActivePeriods {
+		if ap.Contains(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// bounds returns the first & last dates on which the calendar may be active
+func (c Calendar) bounds() (first, last time.Time) {
+	if !c.ValidityPattern.IsZero() {
+		return c.ValidityPattern.bounds()
+	}
+	for _, ap := range c.ActivePeriods {
+		first, last = extend(first, last, civilDate(ap.Begin), civilDate(ap.End).AddDate(0, 0, -1))
+	}
+	for _, e := range c.Exceptions {
+		if e.Type == ExceptionAdd {
+			d := civilDate(e.Date)
+			first, last = extend(first, last, d, d)
+		}
+	}
+	return first, last
+}
+
+// RunsOn reports whether the vehicle journey runs on the given service date.
+//
+// The validity pattern, which gives the actual dates of the vehicle journey, is used if present.
+// Otherwise the vehicle journey runs if one of its calendars is active.
+//
+// As with Navitia's dates, only the wall-clock date of the given time is taken into account, whatever its location.
+func (vj VehicleJourney) RunsOn(date time.Time) bool {
+	if !vj.ValidityPattern.IsZero() {
+		return vj.ValidityPattern.RunsOn(date)
+	}
+	for _, c := range vj.Calendars {
+		if c.RunsOn(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextRunDates returns up to n service dates on which the vehicle journey runs, starting from the date of the given time included.
+// The dates are given at midnight UTC, as Navitia's.
+func (vj VehicleJourney) NextRunDates(from time.Time, n int) []time.Time {
+	if n <= 0 {
+		return nil
+	}
+	first, last := vj.bounds()
+	if from = civilDate(from); from.After(first) {
+		first = from
+	}
+
+	var dates []time.Time
+	for d := first; !d.After(last) && len(dates) < n; d = d.AddDate(0, 0, 1) {
+		if vj.RunsOn(d) {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
+
+// ActiveDates returns the service dates on which the vehicle journey runs during the period,
+// from the date of its beginning to the date of its end, both included.
+// The dates are given at midnight UTC, as Navitia's.
+func (vj VehicleJourney) ActiveDates(period Period) []time.Time {
+	first, last := vj.bounds()
+	if begin := civilDate(period.Begin); begin.After(first) {
+		first = begin
+	}
+	if end := civilDate(period.End); end.Before(last) {
+		last = end
+	}
+
+	var dates []time.Time
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		if vj.RunsOn(d) {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
+
+// bounds returns the first & last dates on which the vehicle journey may run.
+// If it can't run at all, last is before first.
+func (vj VehicleJourney) bounds() (first, last time.Time) {
+	if !vj.ValidityPattern.IsZero() {
+		return vj.ValidityPattern.bounds()
+	}
+	for _, c := range vj.Calendars {
+		cFirst, cLast := c.bounds()
+		if !cFirst.IsZero() {
+			first, last = extend(first, last, cFirst, cLast)
+		}
+	}
+	if first.IsZero() {
+		return first, first.AddDate(0, 0, -1)
+	}
+	return first, last
+}
+
+// extend extends the range of dates from first to last so that it includes the one from begin to end
+func extend(first, last, begin, end time.Time) (time.Time, time.Time) {
+	if first.IsZero() || begin.Before(first) {
+		first = begin
+	}
+	if last.IsZero() || end.After(last) {
+		last = end
+	}
+	return first, last
+}
+
+// civilDate returns the wall-clock date of t, at midnight UTC
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween returns the number of days from the date of a to the date of b
+func daysBetween(a, b time.Time) int {
+	return int(civilDate(b).Sub(civilDate(a)) / (24 * time.Hour))
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testCalendarVJ runs on weekdays of May 2017, except on the 8th, a holiday, and on Sunday the 14th
+const testCalendarVJ = `{
+	"id": "vehicle_journey:1",
+	"calendars": [{
+		"active_periods": [{"begin": "20170501", "end": "20170601"}],
+		"week_pattern": {"monday": true, "tuesday": true, "wednesday": true, "thursday": true, "friday": true, "saturday": false, "sunday": false},
+		"exceptions": [{"type": "Remove", "datetime": "20170508"}, {"type": "add", "datetime": "20170514"}]
+	}]
+}`
+
+// may returns the given day of May 2017
+func may(day int) time.Time {
+	return time.Date(2017, time.May, day, 0, 0, 0, 0, time.UTC)
+}
+
+// TestVehicleJourney_RunsOn checks the evaluation of calendars & validity patterns
+func TestVehicleJourney_RunsOn(t *testing.T) {
+	var vj VehicleJourney
+	if err := json.Unmarshal([]byte(testCalendarVJ), &vj); err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone unavailable: %v", err)
+	}
+	tests := []struct {
+		date time.Time
+		want bool
+	}{
+		{may(2), true},
+		{may(6), false}, // Saturday
+		{may(8), false}, // Removed
+		{may(14), true}, // Added
+		{may(31), true}, // Last day of the period
+		{time.Date(2017, time.June, 1, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2017, time.May, 2, 23, 30, 0, 0, paris), true}, // The wall-clock date counts, not the instant
+		{time.Date(2017, time.April, 28, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, test := range tests {
+		if got := vj.RunsOn(test.date); got != test.want {
+			t.Errorf("RunsOn(%v): got %t, want %t", test.date, got, test.want)
+		}
+	}
+
+	if got, want := vj.NextRunDates(may(5), 3), []time.Time{may(5), may(9), may(10)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NextRunDates: got %v, want %v", got, want)
+	}
+	if got := vj.NextRunDates(may(31), 3); len(got) != 1 {
+		t.Errorf("NextRunDates: expected only the last date, got %v", got)
+	}
+	if got, want := vj.ActiveDates(Period{Begin: may(12), End: may(15)}), []time.Time{may(12), may(14), may(15)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ActiveDates: got %v, want %v", got, want)
+	}
+
+	// The validity pattern takes precedence, its rightmost day being the beginning date
+	vj.ValidityPattern = ValidityPattern{BeginningDate: may(1), Days: "0110"}
+	if got, want := vj.NextRunDates(may(1), 5), []time.Time{may(2), may(3)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NextRunDates with a validity pattern: got %v, want %v", got, want)
+	}
+}
+
+// TestValidityPattern_UnmarshalJSON checks that malformed validity patterns are rejected
+func TestValidityPattern_UnmarshalJSON(t *testing.T) {
+	var vp ValidityPattern
+	if err := json.Unmarshal([]byte(`{"beginning_date": "20170501", "days": "0110"}`), &vp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !vp.BeginningDate.Equal(may(1)) || !vp.RunsOn(may(3)) || vp.RunsOn(may(4)) {
+		t.Errorf("unexpected validity pattern: %+v", vp)
+	}
+
+	for _, raw := range []string{`{"beginning_date": "2017-05-01", "days": "01"}`, `{"beginning_date": "20170501", "days": "012"}`} {
+		if err := json.Unmarshal([]byte(raw), &vp); err == nil {
+			t.Errorf("expected an error for %s", raw)
+		}
+	}
+}
@@ -1,9 +1,35 @@
 package types
 
+// A ChannelType is a kind of media a message may be sent through
+type ChannelType string
+
+// ChannelXXX are the known channel types
+const (
+	ChannelWeb          ChannelType = "web"
+	ChannelSMS          ChannelType = "sms"
+	ChannelEmail        ChannelType = "email"
+	ChannelMobile       ChannelType = "mobile"
+	ChannelNotification ChannelType = "notification"
+	ChannelTwitter      ChannelType = "twitter"
+	ChannelFacebook     ChannelType = "facebook"
+	ChannelTitle        ChannelType = "title"
+	ChannelBeacon       ChannelType = "beacon"
+)
+
 // A Channel is a destination media for a message.
 type Channel struct {
-	ID          ID       `json:"id"`              // ID of the address
-	ContentType string   `json:"content_type"`    // Content Type (text/html etc.) RFC1341.4
-	Name        string   `json:"name"`            // Name of the channel
-	Types       []string `json:"types,omitempty"` // Types ?
+	ID          ID            `json:"id"`              // ID of the address
+	ContentType string        `json:"content_type"`    // Content Type (text/html etc.) RFC1341.4
+	Name        string        `json:"name"`            // Name of the channel
+	Types       []ChannelType `json:"types,omitempty"` // Kinds of media this channel is meant for
+}
+
+// Has reports whether the channel is meant for the given type of media
+func (c Channel) Has(t ChannelType) bool {
+	for _, ct := range c.Types {
+		if ct == t {
+			return true
+		}
+	}
+	return false
 }
@@ -260,6 +260,31 @@ func (c *Container) Place() (Place, error) {
 	return obj.(Place), nil
 }
 
+// Coord returns the coordinates of the Place contained in the container.
+//
+// If the container doesn't hold a Place, or one without coordinates, Coord returns false.
+func (c *Container) Coord() (Coordinates, bool) {
+	obj, err := c.Object()
+	if err != nil {
+		return Coordinates{}, false
+	}
+
+	var coord Coordinates
+	switch o := obj.(type) {
+	case *StopArea:
+		coord = o.Coord
+	case *StopPoint:
+		coord = o.Coord
+	case *Address:
+		coord = o.Coord
+	case *POI:
+		coord = o.Coord
+	case *Admin:
+		coord = o.Coord
+	}
+	return coord, coord != Coordinates{}
+}
+
 // PTObject returns the PTObject contained in the container if that is what's inside
 //
 // If the Object isn't a PTObject or the Container is empty or invalid, Place returns an error
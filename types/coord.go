@@ -3,7 +3,11 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
 // Coordinates code for coordinates used throughout the API.
@@ -25,6 +29,43 @@ func (c Coordinates) ID() ID {
 	return ID(fmt.Sprintf("%3.3f;%3.3f", c.Longitude, c.Latitude))
 }
 
+// earthRadius is the mean radius of the Earth, in meters
+const earthRadius = 6371008.8
+
+// DistanceTo returns the great-circle distance in meters between the two coordinates, using the haversine formula.
+func (c Coordinates) DistanceTo(other Coordinates) float64 {
+	lat1, lat2 := radians(c.Latitude), radians(other.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(other.Longitude - c.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// radians converts degrees to radians
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// ParseCoordinates parses coordinates formatted as an ID ("lon;lat"), as created by Coordinates.ID.
+func ParseCoordinates(id ID) (Coordinates, error) {
+	splitted := strings.Split(string(id), ";")
+	if len(splitted) != 2 {
+		return Coordinates{}, errors.Errorf("ParseCoordinates: \"%s\" isn't formatted as \"lon;lat\"", id)
+	}
+
+	lon, err := strconv.ParseFloat(splitted[0], 64)
+	if err != nil {
+		return Coordinates{}, errors.Wrap(err, "ParseCoordinates: error while parsing longitude")
+	}
+	lat, err := strconv.ParseFloat(splitted[1], 64)
+	if err != nil {
+		return Coordinates{}, errors.Wrap(err, "ParseCoordinates: error while parsing latitude")
+	}
+
+	return Coordinates{Longitude: lon, Latitude: lat}, nil
+}
+
 // UnmarshalJSON implements json.Unmarshaller for a Coordinates
 func (c *Coordinates) UnmarshalJSON(b []byte) error {
 	var data jsonCoordinates
@@ -1,18 +1,160 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// A Departure is a departure from a stop point, as returned by the departures & arrivals APIs.
 type Departure struct {
-	DisplayInformations Display   `json:"display_informations"`
-	StopPoint           StopPoint `json:"stop_point"`
-	Route               Route     `json:"route"`
-	Links               []Link    `json:"links"`
-	StopDateTime
+	DisplayInformations Display      `json:"display_informations"`
+	StopPoint           StopPoint    `json:"stop_point"`
+	Route               Route        `json:"route"`
+	Links               []Link       `json:"links"`
+	StopDateTime        StopDateTime `json:"stop_date_time"`
+}
+
+// jsonDeparture define the JSON implementation of Departure struct
+// We define some of the value as pointers to the real values,
+// allowing us to bypass copying in cases where we don't need to process the data.
+type jsonDeparture struct {
+	DisplayInformations *Display      `json:"display_informations"`
+	StopPoint           *StopPoint    `json:"stop_point"`
+	Route               *Route        `json:"route"`
+	Links               *[]Link       `json:"links"`
+	StopDateTime        *StopDateTime `json:"stop_date_time"`
+}
+
+// UnmarshalJSON implements json.Unmarshaller for a Departure.
+// The stop date times are located in the time zone of the stop point, when known.
+func (d *Departure) UnmarshalJSON(b []byte) error {
+	data := &jsonDeparture{
+		DisplayInformations: &d.DisplayInformations,
+		StopPoint:           &d.StopPoint,
+		Route:               &d.Route,
+		Links:               &d.Links,
+		StopDateTime:        &d.StopDateTime,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return fmt.Errorf("error while unmarshalling Departure: %w", err)
+	}
+
+	d.StopDateTime.Localize(d.StopPoint.TimeLocation())
+	return nil
 }
 
+// A StopDateTime holds the base-scheduled and the actual times at which a vehicle calls at a stop.
 type StopDateTime struct {
-	Links                 []Link `json:"links"`
+	Links []Link `json:"links"`
+
+	// Actual arrival and departure times, realtime ones if the data freshness is realtime.
+	// As the API gives wall-clock times, they are in UTC unless located through Localize.
+	Arrival   time.Time
+	Departure time.Time
+
+	// Base-scheduled arrival and departure times
+	BaseArrival   time.Time
+	BaseDeparture time.Time
+
+	// DataFreshness tells where the times come from: realtime, base_schedule or adapted_schedule
+	DataFreshness DataFreshness `json:"data_freshness"`
+
+	// Additional informations on the stop, such as "pick_up_only", "drop_off_only", "on_demand_transport" or "date_time_estimated"
+	AdditionalInformations []string `json:"additional_informations"`
+
+	// Raw datetimes, as given by the server
 	ArrivalDateTime       string `json:"arrival_date_time"`
 	DepartureDateTime     string `json:"departure_date_time"`
 	BaseArrivalDateTime   string `json:"base_arrival_date_time"`
 	BaseDepartureDateTime string `json:"base_departure_date_time"`
-	DataFreshness         string `json:"data_freshness"`
+}
+
+// jsonStopDateTime define the JSON implementation of StopDateTime struct
+// We define some of the value as pointers to the real values,
+// allowing us to bypass copying in cases where we don't need to process the data.
+type jsonStopDateTime struct {
+	Links                  *[]Link        `json:"links"`
+	DataFreshness          *DataFreshness `json:"data_freshness"`
+	AdditionalInformations *[]string      `json:"additional_informations"`
+
+	// Values to process, while keeping the raw value
+	ArrivalDateTime       *string `json:"arrival_date_time"`
+	DepartureDateTime     *string `json:"departure_date_time"`
+	BaseArrivalDateTime   *string `json:"base_arrival_date_time"`
+	BaseDepartureDateTime *string `json:"base_departure_date_time"`
+}
+
+// UnmarshalJSON implements json.Unmarshaller for a StopDateTime
+func (sdt *StopDateTime) UnmarshalJSON(b []byte) error {
+	data := &jsonStopDateTime{
+		Links:                  &sdt.Links,
+		DataFreshness:          &sdt.DataFreshness,
+		AdditionalInformations: &sdt.AdditionalInformations,
+		ArrivalDateTime:        &sdt.ArrivalDateTime,
+		DepartureDateTime:      &sdt.DepartureDateTime,
+		BaseArrivalDateTime:    &sdt.BaseArrivalDateTime,
+		BaseDepartureDateTime:  &sdt.BaseDepartureDateTime,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return fmt.Errorf("error while unmarshalling StopDateTime: %w", err)
+	}
+
+	// Create the error generator
+	gen := unmarshalErrorMaker{"StopDateTime", b}
+
+	// Now parse the datetimes
+	sdt.Arrival, err = parseDateTime(sdt.ArrivalDateTime)
+	if err != nil {
+		return gen.err(err, "Arrival", "arrival_date_time", sdt.ArrivalDateTime, "parseDateTime failed")
+	}
+	sdt.Departure, err = parseDateTime(sdt.DepartureDateTime)
+	if err != nil {
+		return gen.err(err, "Departure", "departure_date_time", sdt.DepartureDateTime, "parseDateTime failed")
+	}
+	sdt.BaseArrival, err = parseDateTime(sdt.BaseArrivalDateTime)
+	if err != nil {
+		return gen.err(err, "BaseArrival", "base_arrival_date_time", sdt.BaseArrivalDateTime, "parseDateTime failed")
+	}
+	sdt.BaseDeparture, err = parseDateTime(sdt.BaseDepartureDateTime)
+	if err != nil {
+		return gen.err(err, "BaseDeparture", "base_departure_date_time", sdt.BaseDepartureDateTime, "parseDateTime failed")
+	}
+
+	return nil
+}
+
+// Delay returns the difference between the actual and the base-scheduled times, using the departure if possible, else the arrival.
+// A negative delay means the vehicle is early.
+// If there's no base-scheduled time, Delay returns 0.
+func (sdt StopDateTime) Delay() time.Duration {
+	switch {
+	case !sdt.Departure.IsZero() && !sdt.BaseDeparture.IsZero():
+		return sdt.Departure.Sub(sdt.BaseDeparture)
+	case !sdt.Arrival.IsZero() && !sdt.BaseArrival.IsZero():
+		return sdt.Arrival.Sub(sdt.BaseArrival)
+	default:
+		return 0
+	}
+}
+
+// IsRealtime reports whether the times come from realtime data.
+func (sdt StopDateTime) IsRealtime() bool {
+	return sdt.DataFreshness == DataFreshnessRealTime
+}
+
+// Localize locates the times in the given time zone, keeping their wall clock.
+// Navitia gives wall-clock times of the stop's time zone, so this is the time zone of the stop area, or else of the region.
+// A nil location leaves the times unchanged.
+func (sdt *StopDateTime) Localize(loc *time.Location) {
+	sdt.Arrival = InLocation(sdt.Arrival, loc)
+	sdt.Departure = InLocation(sdt.Departure, loc)
+	sdt.BaseArrival = InLocation(sdt.BaseArrival, loc)
+	sdt.BaseDeparture = InLocation(sdt.BaseDeparture, loc)
 }
@@ -21,6 +21,7 @@ type Display struct {
 	Equipments     []Equipment `json:"equipments"`      // Equipments on this object
 	Name           string      `json:"name"`            // Name of object
 	TripShortName  string      `json:"trip_short_name"` // TripShoerName short name of the current trip
+	Links          []Link      `json:"links"`           // Links to related objects, such as the disruptions impacting it
 }
 
 // jsonDisplay define the JSON implementation of Display struct
@@ -37,6 +38,7 @@ type jsonDisplay struct {
 	Code           *string      `json:"code"`
 	Description    *string      `json:"description"`
 	Equipments     *[]Equipment `json:"equipments"`
+	Links          *[]Link      `json:"links"`
 
 	// Values to process
 	Color     string `json:"color"`
@@ -57,6 +59,7 @@ func (d *Display) UnmarshalJSON(b []byte) error {
 		Code:           &d.Code,
 		Description:    &d.Description,
 		Equipments:     &d.Equipments,
+		Links:          &d.Links,
 	}
 
 	// Now unmarshall the raw data into the analogous structure
@@ -17,6 +17,9 @@ const (
 	// Service suspended.
 	EffectNoService Effect = "NO_SERVICE"
 
+	// Service running but with substantial delays expected, as given by the API.
+	EffectSignificantDelays Effect = "SIGNIFICANT_DELAYS"
+
 	// Service running at lowered capacity.
 	JourneyStatusReducedService = "REDUCED_SERVICE"
 
@@ -48,19 +51,32 @@ type Disruption struct {
 
 	// State of the disruption.
 	// The state is computed using the application_periods of the disruption and the current time of the query.
-	// It can be either "Past", "Active" or "Future"
+	// It can be either "past", "active" or "future"
 	Status string `json:"status"`
 
-	InputDisruptionID ID               // For traceability, ID of original input disruption
-	InputImpactID     ID               // For traceability: Id of original input impact
-	Severity          Severity         `json:"severity"` // Severity gives some categorization element
-	Periods           []Period         // Dates where the current disruption is active
-	Messages          []Message        // Text to provide to the traveller
-	LastUpdated       time.Time        // Last Update of that disruption
-	Impacted          []ImpactedObject `json:"impacted_stops"` // Objects impacted
-	Cause             string           // The cause of that disruption
-	Category          string           // The category of the disruption, optional.
-	DisruptionID      string           `json:"disruption_id"`
+	InputDisruptionID ID                   // For traceability, ID of original input disruption
+	InputImpactID     ID                   // For traceability: Id of original input impact
+	DisruptionURI     ID                   // ID of the disruption, shared by all of its impacts
+	Contributor       string               // Contributor of the disruption, such as a realtime feed
+	Severity          Severity             `json:"severity"` // Severity gives some categorization element
+	Periods           []Period             // Dates where the current disruption is active
+	Messages          []Message            // Text to provide to the traveller
+	LastUpdated       time.Time            // Last Update of that disruption
+	Impacted          []ImpactedObject     `json:"impacted_objects"` // Objects impacted
+	Cause             string               // The cause of that disruption
+	Category          string               // The category of the disruption, optional.
+	Tags              []string             // Tags of the disruption, to classify it
+	Properties        []DisruptionProperty // Free properties, given by the contributor
+
+	// Deprecated: use InputDisruptionID
+	DisruptionID string `json:"disruption_id"`
+}
+
+// A DisruptionProperty is a free property attached to a disruption by its contributor.
+type DisruptionProperty struct {
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
 }
 
 // jsonDisruption define the JSON implementation of Disruption struct
@@ -68,16 +84,20 @@ type Disruption struct {
 // allowing us to bypass copying in cases where we don't need to process the data.
 type jsonDisruption struct {
 	// The references
-	ID                *ID               `json:"id"`
-	Status            *string           `json:"status"`
-	InputDisruptionID *ID               `json:"disruption_id"`
-	InputImpactID     *ID               `json:"impact_id"`
-	Severity          *Severity         `json:"severity"`
-	Periods           *[]Period         `json:"application_periods"`
-	Messages          *[]Message        `json:"messages"`
-	Impacted          *[]ImpactedObject `json:"impacted_objects"`
-	Cause             *string           `json:"cause"`
-	Category          *string           `json:"category"`
+	ID                *ID                   `json:"id"`
+	Status            *string               `json:"status"`
+	InputDisruptionID *ID                   `json:"disruption_id"`
+	InputImpactID     *ID                   `json:"impact_id"`
+	DisruptionURI     *ID                   `json:"disruption_uri"`
+	Contributor       *string               `json:"contributor"`
+	Severity          *Severity             `json:"severity"`
+	Periods           *[]Period             `json:"application_periods"`
+	Messages          *[]Message            `json:"messages"`
+	Impacted          *[]ImpactedObject     `json:"impacted_objects"`
+	Cause             *string               `json:"cause"`
+	Category          *string               `json:"category"`
+	Tags              *[]string             `json:"tags"`
+	Properties        *[]DisruptionProperty `json:"properties"`
 
 	// Those we will process
 	LastUpdated string `json:"updated_at"`
@@ -98,12 +118,16 @@ func (d *Disruption) UnmarshalJSON(b []byte) error {
 		Status:            &d.Status,
 		InputDisruptionID: &d.InputDisruptionID,
 		InputImpactID:     &d.InputImpactID,
+		DisruptionURI:     &d.DisruptionURI,
+		Contributor:       &d.Contributor,
 		Severity:          &d.Severity,
 		Periods:           &d.Periods,
 		Messages:          &d.Messages,
 		Impacted:          &d.Impacted,
 		Cause:             &d.Cause,
 		Category:          &d.Category,
+		Tags:              &d.Tags,
+		Properties:        &d.Properties,
 	}
 
 	// Let's create the error generator
@@ -115,6 +139,8 @@ func (d *Disruption) UnmarshalJSON(b []byte) error {
 		return fmt.Errorf("error while unmarshalling Disruption: %w", err)
 	}
 
+	d.DisruptionID = string(d.InputDisruptionID)
+
 	// Now we process the Update time
 	d.LastUpdated, err = parseDateTime(data.LastUpdated)
 	if err != nil {
@@ -0,0 +1,111 @@
+package types
+
+import "time"
+
+// ResolveDisruptions finds, among the given disruptions, those impacting each section of the journey, and stores them in the section's Disruptions.
+//
+// A disruption impacts a section when the section links to it, or when it impacts one of the objects the section uses
+// (vehicle journey, line, route, network, modes, stops) during the section's travel.
+func (j *Journey) ResolveDisruptions(disruptions []Disruption) {
+	for i := range j.Sections {
+		s := &j.Sections[i]
+		s.Disruptions = nil
+		for _, d := range disruptions {
+			if d.Impacts(*s) {
+				s.Disruptions = append(s.Disruptions, d)
+			}
+		}
+	}
+}
+
+// Disruptions returns the disruptions impacting the journey's sections, each once, as resolved by ResolveDisruptions.
+func (j Journey) Disruptions() []Disruption {
+	var disruptions []Disruption
+	seen := map[ID]bool{}
+	for _, s := range j.Sections {
+		for _, d := range s.Disruptions {
+			if !seen[d.ID] {
+				seen[d.ID] = true
+				disruptions = append(disruptions, d)
+			}
+		}
+	}
+	return disruptions
+}
+
+// Impacts reports whether the disruption impacts the given section.
+//
+// This is the case if the section or its display informations link to the disruption, or if one of the disruption's
+// impacted objects is used by the section and one of its application periods overlaps the section's travel.
+func (d Disruption) Impacts(s Section) bool {
+	// The server's own references come first
+	for _, links := range [][]Link{s.Links, s.Display.Links} {
+		for _, l := range links {
+			if l.Type == "disruption" && l.ID == d.ID {
+				return true
+			}
+		}
+	}
+
+	// Then the impacted objects
+	objects := sectionObjects(s)
+	impacted := false
+	for _, io := range d.Impacted {
+		if objects[io.Object.ID] {
+			impacted = true
+			break
+		}
+	}
+	if !impacted {
+		return false
+	}
+
+	return d.activeDuring(s.Departure, s.Arrival)
+}
+
+// sectionObjects returns the set of the IDs of the objects used by the section.
+// The ends of a section are only its stops for public transport: those of a walk, such as a stop area the walk
+// merely leads to, aren't used by it.
+func sectionObjects(s Section) map[ID]bool {
+	objects := map[ID]bool{}
+	add := func(id ID) {
+		if id != "" {
+			objects[id] = true
+		}
+	}
+
+	for _, l := range s.Links {
+		add(l.ID)
+	}
+	if s.Type == SectionPublicTransport {
+		add(s.From.ID)
+		add(s.To.ID)
+	}
+	for _, st := range s.StopTimes {
+		add(st.StopPoint.ID)
+		if st.StopPoint.StopArea != nil {
+			add(st.StopPoint.StopArea.ID)
+		}
+	}
+	return objects
+}
+
+// activeDuring reports whether one of the application periods of the disruption overlaps the given interval.
+// A disruption without application periods is considered always active.
+// As Navitia gives wall-clock times, they are compared whatever their location.
+func (d Disruption) activeDuring(begin, end time.Time) bool {
+	if len(d.Periods) == 0 {
+		return true
+	}
+	begin, end = InLocation(begin, time.UTC), InLocation(end, time.UTC)
+	if end.IsZero() {
+		end = begin
+	}
+	for _, p := range d.Periods {
+		pBegin, pEnd := InLocation(p.Begin, time.UTC), InLocation(p.End, time.UTC)
+		if (pEnd.IsZero() || !pEnd.Before(begin)) && (pBegin.IsZero() || !pBegin.After(end)) {
+			return true
+		}
+	}
+	return false
+}
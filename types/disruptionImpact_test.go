@@ -0,0 +1,90 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestDisruption_Impacts checks the resolution of disruptions onto sections, through links, impacted objects and application periods
+func TestDisruption_Impacts(t *testing.T) {
+	var section Section
+	err := json.Unmarshal([]byte(`{
+		"type": "public_transport",
+		"departure_date_time": "20160608T220000",
+		"arrival_date_time": "20160608T221500",
+		"links": [
+			{"id": "vehicle_journey:RAT:1", "type": "vehicle_journey"},
+			{"id": "line:RAT:M14", "type": "line"}
+		],
+		"display_informations": {"links": [{"id": "linked", "type": "disruption"}]}
+	}`), &section)
+	if err != nil {
+		t.Fatalf("unexpected error while unmarshalling Section: %v", err)
+	}
+
+	disruption := func(raw string) Disruption {
+		var d Disruption
+		if err := json.Unmarshal([]byte(raw), &d); err != nil {
+			t.Fatalf("unexpected error while unmarshalling Disruption: %v", err)
+		}
+		return d
+	}
+	tests := []struct {
+		name string
+		d    Disruption
+		want bool
+	}{
+		{"linked", disruption(`{"id": "linked"}`), true},
+		{"line, active", disruption(`{"id": "a", "impacted_objects": [{"pt_object": {"id": "line:RAT:M14", "embedded_type": "line"}}], "application_periods": [{"begin": "20160608T215400", "end": "20160608T230959"}]}`), true},
+		{"line, no period", disruption(`{"id": "b", "impacted_objects": [{"pt_object": {"id": "line:RAT:M14", "embedded_type": "line"}}]}`), true},
+		{"line, inactive", disruption(`{"id": "c", "impacted_objects": [{"pt_object": {"id": "line:RAT:M14", "embedded_type": "line"}}], "application_periods": [{"begin": "20160609T000000", "end": "20160609T230959"}]}`), false},
+		{"other line", disruption(`{"id": "d", "impacted_objects": [{"pt_object": {"id": "line:RAT:M1", "embedded_type": "line"}}]}`), false},
+	}
+	for _, tt := range tests {
+		if got := tt.d.Impacts(section); got != tt.want {
+			t.Errorf("%s: got %t, want %t", tt.name, got, tt.want)
+		}
+	}
+
+	// Now resolve them all onto a journey
+	j := Journey{Sections: []Section{section}}
+	var all []Disruption
+	for _, tt := range tests {
+		all = append(all, tt.d)
+	}
+	j.ResolveDisruptions(all)
+	if got := len(j.Disruptions()); got != 3 {
+		t.Errorf("expected 3 disruptions on the journey, got %d", got)
+	}
+}
+
+// TestDisruption_Impacts_walk checks that a walk to a disrupted stop area isn't impacted by the disruption, unlike the ride from it
+func TestDisruption_Impacts_walk(t *testing.T) {
+	var d Disruption
+	if err := json.Unmarshal([]byte(`{"id": "closed", "impacted_objects": [{"pt_object": {"id": "stop_area:RAT:SA:BERCY", "embedded_type": "stop_area"}}]}`), &d); err != nil {
+		t.Fatalf("unexpected error while unmarshalling Disruption: %v", err)
+	}
+
+	var walk, ride Section
+	if err := json.Unmarshal([]byte(`{
+		"type": "street_network",
+		"from": {"id": "2.37;48.84", "embedded_type": "address"},
+		"to": {"id": "stop_area:RAT:SA:BERCY", "embedded_type": "stop_area"}
+	}`), &walk); err != nil {
+		t.Fatalf("unexpected error while unmarshalling Section: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{
+		"type": "public_transport",
+		"from": {"id": "stop_area:RAT:SA:BERCY", "embedded_type": "stop_area"},
+		"to": {"id": "stop_area:RAT:SA:OLYMP", "embedded_type": "stop_area"}
+	}`), &ride); err != nil {
+		t.Fatalf("unexpected error while unmarshalling Section: %v", err)
+	}
+
+	if d.Impacts(walk) {
+		t.Error("the walk to the stop area shouldn't be impacted")
+	}
+	if !d.Impacts(ride) {
+		t.Error("the ride from the stop area should be impacted")
+	}
+}
@@ -0,0 +1,144 @@
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+// ActiveAt reports whether the disruption is in effect at the given time, according to its application periods.
+// A disruption without application periods is considered always active.
+//
+// As Navitia gives wall-clock times of the region, the comparison is done on the wall clock of the given time, whatever its location.
+func (d Disruption) ActiveAt(t time.Time) bool {
+	return d.activeDuring(t, t)
+}
+
+// Affects reports whether the disruption impacts the object with the given ID.
+//
+// This covers the impacted objects themselves, the ends and routes (and their lines) of impacted line sections,
+// and the stop points (and their stop areas) of impacted stops.
+func (d Disruption) Affects(id ID) bool {
+	for _, affected := range d.affectedIDs() {
+		if affected == id {
+			return true
+		}
+	}
+	return false
+}
+
+// affectedIDs returns the IDs of the objects impacted by the disruption, see Affects
+func (d Disruption) affectedIDs() []ID {
+	var ids []ID
+	add := func(id ID) {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	for _, io := range d.Impacted {
+		add(io.Object.ID)
+
+		add(io.ImpactedSection.From.ID)
+		add(io.ImpactedSection.To.ID)
+		for _, r := range io.ImpactedSection.Routes {
+			add(r.ID)
+			add(r.Line.ID)
+		}
+
+		for _, is := range io.ImpactedStops {
+			add(is.Point.ID)
+			if is.Point.StopArea != nil {
+				add(is.Point.StopArea.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// SortBySeverity sorts the disruptions from the most to the least severe, see Severity.Compare.
+// The order of equally severe disruptions is kept.
+func SortBySeverity(disruptions []Disruption) {
+	sort.SliceStable(disruptions, func(i, j int) bool {
+		return disruptions[i].Severity.Compare(disruptions[j].Severity) > 0
+	})
+}
+
+// A DisruptionSet indexes a batch of disruptions by the objects they impact, such as lines & stops, for fast lookup.
+//
+// As it is immutable once created, it is safe for concurrent use.
+type DisruptionSet struct {
+	disruptions []Disruption
+	byObject    map[ID][]int
+}
+
+// NewDisruptionSet creates a DisruptionSet indexing the given disruptions.
+// Disruptions sharing the same ID are only kept once, the first one given winning.
+func NewDisruptionSet(disruptions []Disruption) *DisruptionSet {
+	set := &DisruptionSet{
+		disruptions: make([]Disruption, 0, len(disruptions)),
+		byObject:    make(map[ID][]int),
+	}
+
+	seen := make(map[ID]bool, len(disruptions))
+	for _, d := range disruptions {
+		if d.ID != "" && seen[d.ID] {
+			continue
+		}
+		seen[d.ID] = true
+		set.disruptions = append(set.disruptions, d)
+
+		// Index it, once per object
+		n := len(set.disruptions) - 1
+		indexed := map[ID]bool{}
+		for _, id := range d.affectedIDs() {
+			if !indexed[id] {
+				indexed[id] = true
+				set.byObject[id] = append(set.byObject[id], n)
+			}
+		}
+	}
+
+	return set
+}
+
+// Len returns the amount of disruptions in the set
+func (set *DisruptionSet) Len() int {
+	return len(set.disruptions)
+}
+
+// Disruptions returns every disruption of the set
+func (set *DisruptionSet) Disruptions() []Disruption {
+	return set.disruptions
+}
+
+// Affecting returns the disruptions affecting the given object, from the most to the least severe.
+func (set *DisruptionSet) Affecting(id ID) []Disruption {
+	indexes := set.byObject[id]
+	found := make([]Disruption, len(indexes))
+	for i, n := range indexes {
+		found[i] = set.disruptions[n]
+	}
+	SortBySeverity(found)
+	return found
+}
+
+// ActiveAt returns the disruptions affecting the given object at the given time, from the most to the least severe.
+func (set *DisruptionSet) ActiveAt(id ID, t time.Time) []Disruption {
+	var found []Disruption
+	for _, d := range set.Affecting(id) {
+		if d.ActiveAt(t) {
+			found = append(found, d)
+		}
+	}
+	return found
+}
+
+// Worst returns the most severe disruption affecting the given object at the given time.
+// If none does, ok is false.
+func (set *DisruptionSet) Worst(id ID, t time.Time) (d Disruption, ok bool) {
+	active := set.ActiveAt(id, t)
+	if len(active) == 0 {
+		return Disruption{}, false
+	}
+	return active[0], true
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestSeverity_Compare checks the ordering of severities, by effect then priority
+func TestSeverity_Compare(t *testing.T) {
+	one, two := 1, 2
+	tests := []struct {
+		name        string
+		a, b        Severity
+		aMoreSevere bool
+	}{
+		{"worse effect, higher priority", Severity{Priority: &two, Effect: EffectNoService}, Severity{Priority: &one, Effect: EffectSignificantDelays}, true},
+		{"undefined priority, worse effect", Severity{Effect: EffectNoService}, Severity{Priority: &two, Effect: JourneyStatusOtherEffect}, true},
+		{"undefined priority, milder effect", Severity{Effect: JourneyStatusOtherEffect}, Severity{Priority: &one, Effect: EffectNoService}, false},
+		{"same effect, lower priority", Severity{Priority: &one, Effect: EffectNoService}, Severity{Priority: &two, Effect: EffectNoService}, true},
+		{"same effect, undefined priority", Severity{Effect: EffectNoService}, Severity{Priority: &two, Effect: EffectNoService}, false},
+		{"no priority, worse effect", Severity{Effect: JourneyStatusDetour}, Severity{Effect: JourneyStatusUnknownEffect}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Compare(tt.b) > 0; got != tt.aMoreSevere {
+			t.Errorf("%s: got a more severe = %t, want %t", tt.name, got, tt.aMoreSevere)
+		}
+		if got := tt.b.Compare(tt.a) < 0; got != tt.aMoreSevere {
+			t.Errorf("%s: comparison isn't antisymmetric", tt.name)
+		}
+	}
+}
+
+// TestSeverity_Compare_transitive checks that the ordering is transitive over severities with and without priorities
+func TestSeverity_Compare_transitive(t *testing.T) {
+	one, two := 1, 2
+	var severities []Severity
+	for _, p := range []*int{nil, &one, &two} {
+		for _, e := range []Effect{EffectNoService, JourneyStatusDetour, JourneyStatusOtherEffect} {
+			severities = append(severities, Severity{Priority: p, Effect: e})
+		}
+	}
+
+	sign := func(n int) int {
+		switch {
+		case n > 0:
+			return 1
+		case n < 0:
+			return -1
+		}
+		return 0
+	}
+	for _, a := range severities {
+		for _, b := range severities {
+			for _, c := range severities {
+				ab, bc, ac := sign(a.Compare(b)), sign(b.Compare(c)), sign(a.Compare(c))
+				if ab >= 0 && bc >= 0 && ac < 0 {
+					t.Errorf("not transitive: %v >= %v >= %v but %v < %v", a, b, c, a, c)
+				}
+			}
+		}
+	}
+}
+
+// TestDisruptionSet checks the lookup of the disruptions affecting an object
+func TestDisruptionSet(t *testing.T) {
+	var disruptions []Disruption
+	for _, name := range []string{"trip_delayed.json", "line_section.json"} {
+		var d Disruption
+		if err := json.Unmarshal(testData["disruption"].correct[name], &d); err != nil {
+			t.Fatalf("unexpected error while unmarshalling %s: %v", name, err)
+		}
+		disruptions = append(disruptions, d)
+	}
+	// A second disruption on the line, less severe, always active
+	disruptions = append(disruptions, Disruption{
+		ID:       "other",
+		Severity: Severity{Effect: JourneyStatusOtherEffect},
+		Impacted: []ImpactedObject{{Object: Container{ID: "line:RAT:M14"}}},
+	})
+	set := NewDisruptionSet(append(disruptions, disruptions[2]))
+	if set.Len() != 3 {
+		t.Fatalf("expected 3 disruptions, got %d", set.Len())
+	}
+
+	// The line
+	works := time.Date(2017, time.April, 29, 23, 0, 0, 0, time.UTC)
+	if got := set.Affecting("line:RAT:M14"); len(got) != 2 || got[0].Severity.Effect != JourneyStatusReducedService {
+		t.Errorf("line: expected the works first, then the other disruption, got %v", got)
+	}
+	if d, ok := set.Worst("line:RAT:M14", works); !ok || d.Severity.Effect != JourneyStatusReducedService {
+		t.Errorf("line during the works: expected the works, got %v (%t)", d.ID, ok)
+	}
+	if d, ok := set.Worst("line:RAT:M14", works.Add(12*time.Hour)); !ok || d.ID != "other" {
+		t.Errorf("line after the works: expected the other disruption, got %v (%t)", d.ID, ok)
+	}
+
+	// A stop impacted by the works
+	if got := set.ActiveAt("stop_area:RAT:SA:OLYMP", works); len(got) != 1 {
+		t.Errorf("stop area: expected 1 disruption, got %d", len(got))
+	}
+
+	// The delayed trip, through one of its stops
+	delayed := time.Date(2017, time.April, 27, 17, 10, 0, 0, time.UTC)
+	if !disruptions[0].Affects("stop_point:OIF:SP:8738288:800:L") || !disruptions[0].ActiveAt(delayed) || disruptions[0].ActiveAt(works) {
+		t.Error("trip delay: unexpected Affects or ActiveAt result")
+	}
+	if _, ok := set.Worst("line:OIF:unknown", delayed); ok {
+		t.Error("unknown line: expected no disruption")
+	}
+}
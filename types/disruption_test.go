@@ -1,8 +1,10 @@
 package types
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 )
 
 // Test_Disruption_Unmarshal tests unmarshalling for Disruption.
@@ -14,3 +16,62 @@ import (
 func Test_Disruption_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["disruption"], reflect.TypeOf(Disruption{}))
 }
+
+// Test_Disruption_Unmarshal_values checks the values decoded from a full disruption
+func Test_Disruption_Unmarshal_values(t *testing.T) {
+	var d Disruption
+	err := json.Unmarshal(testData["disruption"].correct["trip_delayed.json"], &d)
+	if err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+
+	if d.InputDisruptionID != "disruption:OIF:trip-delay-1234" || d.DisruptionID != string(d.InputDisruptionID) {
+		t.Errorf("disruption ID: got %q & %q", d.InputDisruptionID, d.DisruptionID)
+	}
+	if d.Contributor != "realtime.sncf" || len(d.Tags) != 2 || len(d.Properties) != 1 || d.Properties[0].Type != "url" {
+		t.Errorf("unexpected contributor, tags or properties: %q %v %v", d.Contributor, d.Tags, d.Properties)
+	}
+	if len(d.Messages) != 2 || !d.Messages[0].IsHTML() || !d.Messages[0].Channel.Has(ChannelMobile) || d.Messages[1].IsHTML() {
+		t.Errorf("unexpected messages: %+v", d.Messages)
+	}
+
+	if len(d.Impacted) != 1 || len(d.Impacted[0].ImpactedStops) != 2 {
+		t.Fatalf("expected 1 impacted object with 2 impacted stops, got %+v", d.Impacted)
+	}
+	delayed, deleted := d.Impacted[0].ImpactedStops[0], d.Impacted[0].ImpactedStops[1]
+	if delayed.StopTimeEffect != StopTimeDelayed || delayed.Delay() != 10*time.Minute || delayed.AmendedDeparture.String() != "17:20:00" {
+		t.Errorf("delayed stop: got effect %q, delay %v & departure %s", delayed.StopTimeEffect, delayed.Delay(), delayed.AmendedDeparture)
+	}
+	if deleted.StopTimeEffect != StopTimeDeleted || deleted.Delay() != 0 {
+		t.Errorf("deleted stop: got effect %q & delay %v", deleted.StopTimeEffect, deleted.Delay())
+	}
+
+	// Times are given modulo 24 hours, so delays across midnight are unwrapped
+	acrossMidnight := []struct {
+		base, amended string
+		want          time.Duration
+	}{
+		{"235500", "000500", 10 * time.Minute},
+		{"000500", "235500", -10 * time.Minute},
+	}
+	for _, tt := range acrossMidnight {
+		var is ImpactedStop
+		b := []byte(`{"base_departure_time": "` + tt.base + `", "amended_departure_time": "` + tt.amended + `"}`)
+		if err := json.Unmarshal(b, &is); err != nil {
+			t.Fatalf("unexpected error while unmarshalling impacted stop: %v", err)
+		}
+		if got := is.Delay(); got != tt.want {
+			t.Errorf("delay from %s to %s: got %v, want %v", tt.base, tt.amended, got, tt.want)
+		}
+	}
+
+	// Now a line section
+	err = json.Unmarshal(testData["disruption"].correct["line_section.json"], &d)
+	if err != nil {
+		t.Fatalf("unexpected error while unmarshalling: %v", err)
+	}
+	section := d.Impacted[0].ImpactedSection
+	if section.From.ID != "stop_area:RAT:SA:GDLYO" || section.To.ID != "stop_area:RAT:SA:OLYMP" || len(section.Routes) != 1 {
+		t.Errorf("unexpected impacted section: %+v", section)
+	}
+}
@@ -1,6 +1,51 @@
 package types
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// An ExceptionType tells whether an Exception adds or removes a date from a calendar
+type ExceptionType string
+
+// ExceptionXXX are the known exception types
+const (
+	ExceptionAdd    ExceptionType = "add"
+	ExceptionRemove ExceptionType = "remove"
+)
+
+// An Exception adds or removes a date from a calendar, whatever its active periods & week pattern
 type Exception struct {
-	Type     string `json:"type"`
-	Datetime string `json:"datetime"`
+	Type ExceptionType `json:"type"`
+	Date time.Time     `json:"datetime"`
+}
+
+// UnmarshalJSON implements json.Unmarshaller for an Exception
+func (e *Exception) UnmarshalJSON(b []byte) error {
+	data := &struct {
+		Type     string `json:"type"`
+		Datetime string `json:"datetime"`
+	}{}
+
+	// Let's create the error generator
+	gen := unmarshalErrorMaker{"Exception", b}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "Error while unmarshalling Exception")
+	}
+
+	// The API may capitalize the type
+	e.Type = ExceptionType(strings.ToLower(data.Type))
+
+	e.Date, err = parseDateTime(data.Datetime)
+	if err != nil {
+		return gen.err(err, "Date", "datetime", data.Datetime, "parseDateTime failed")
+	}
+
+	return nil
 }
@@ -1,9 +1,11 @@
 package types
 
-// An ImpactedSection records the impact to a section
+// An ImpactedSection records the impact to a section of a line
 type ImpactedSection struct {
 	// The start of the disruption, spatially
 	From Container `json:"from"`
 	// Until this point
 	To Container `json:"to"`
+	// The routes impacted between these two points
+	Routes []Route `json:"routes"`
 }
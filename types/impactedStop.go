@@ -1,42 +1,136 @@
 package types
 
-// An ImpactedStop records the impact to a stop
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// A StopTimeEffect is the effect of a disruption on a stop time
+type StopTimeEffect string
+
+// StopTimeXXX are the known effects of a disruption on a stop time
+const (
+	// StopTimeAdded means the stop is served in addition to the base schedule
+	StopTimeAdded StopTimeEffect = "added"
+
+	// StopTimeDeleted means the stop isn't served anymore
+	StopTimeDeleted StopTimeEffect = "deleted"
+
+	// StopTimeDelayed means the stop is served at another time
+	StopTimeDelayed StopTimeEffect = "delayed"
+
+	// StopTimeUnchanged means the stop is served as planned
+	StopTimeUnchanged StopTimeEffect = "unchanged"
+)
+
+// An ImpactedStop records the impact to a stop of a trip
 type ImpactedStop struct {
 	// The impacted stop point of the trip
 	Point StopPoint `json:"stop_point"`
 
-	// New departure hour (format HHMMSS) of the trip on this stop point
-	NewDeparture string
-
-	// New arrival hour (format HHMMSS) of the trip on this stop point
-	NewArrival string
+	// Base arrival & departure times of day of the trip on this stop point
+	BaseArrival   ServiceTime
+	BaseDeparture ServiceTime
 
-	// Base departure hour (format HHMMSS) of the trip on this stop point
-	BaseDeparture string
-
-	// Base arrival hour (format HHMMSS) of the trip on this stop point
-	BaseArrival string
+	// Amended arrival & departure times of day of the trip on this stop point
+	AmendedArrival   ServiceTime
+	AmendedDeparture ServiceTime
 
 	// Cause of the modification
 	Cause string `json:"cause"`
 
-	// Effect on that StopPoint
-	// Can be "added", "deleted", "delayed"
-	Effect string
+	// Effect on that stop time, and more specifically on its arrival & departure
+	StopTimeEffect  StopTimeEffect `json:"stop_time_effect"`
+	ArrivalStatus   StopTimeEffect `json:"arrival_status"`
+	DepartureStatus StopTimeEffect `json:"departure_status"`
 
-	AmendedArrivalTime string `json:"amended_arrival_time"`
+	// Is the stop served as part of a detour ?
+	IsDetour bool `json:"is_detour"`
 
-	StopTimeEffect string `json:"stop_time_effect"`
+	// Raw times (format HHMMSS), as given by the server. They are empty when unknown, for example for a deleted stop.
+	BaseArrivalTime      string `json:"base_arrival_time"`
+	BaseDepartureTime    string `json:"base_departure_time"`
+	AmendedArrivalTime   string `json:"amended_arrival_time"`
+	AmendedDepartureTime string `json:"amended_departure_time"`
+}
 
-	DepartureStatus string `json:"departure_status"`
+// jsonImpactedStop define the JSON implementation of ImpactedStop struct
+// We define some of the value as pointers to the real values,
+// allowing us to bypass copying in cases where we don't need to process the data.
+type jsonImpactedStop struct {
+	Point           *StopPoint      `json:"stop_point"`
+	Cause           *string         `json:"cause"`
+	StopTimeEffect  *StopTimeEffect `json:"stop_time_effect"`
+	ArrivalStatus   *StopTimeEffect `json:"arrival_status"`
+	DepartureStatus *StopTimeEffect `json:"departure_status"`
+	IsDetour        *bool           `json:"is_detour"`
 
-	IsDetour bool `json:"is_detour"`
+	// Values to process, while keeping the raw value
+	BaseArrivalTime      *string `json:"base_arrival_time"`
+	BaseDepartureTime    *string `json:"base_departure_time"`
+	AmendedArrivalTime   *string `json:"amended_arrival_time"`
+	AmendedDepartureTime *string `json:"amended_departure_time"`
+}
 
-	AmendedDepartureTime string `json:"amended_departure_time"`
+// UnmarshalJSON implements json.Unmarshaller for an ImpactedStop
+func (is *ImpactedStop) UnmarshalJSON(b []byte) error {
+	data := &jsonImpactedStop{
+		Point:                &is.Point,
+		Cause:                &is.Cause,
+		StopTimeEffect:       &is.StopTimeEffect,
+		ArrivalStatus:        &is.ArrivalStatus,
+		DepartureStatus:      &is.DepartureStatus,
+		IsDetour:             &is.IsDetour,
+		BaseArrivalTime:      &is.BaseArrivalTime,
+		BaseDepartureTime:    &is.BaseDepartureTime,
+		AmendedArrivalTime:   &is.AmendedArrivalTime,
+		AmendedDepartureTime: &is.AmendedDepartureTime,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return fmt.Errorf("error while unmarshalling ImpactedStop: %w", err)
+	}
 
-	BaseArrivalTime string `json:"base_arrival_time"`
+	// Create the error generator
+	gen := unmarshalErrorMaker{"ImpactedStop", b}
 
-	BaseDepartureTime string `json:"base_departure_time"`
+	// Now process the times of day
+	times := []struct {
+		dest *ServiceTime
+		name string
+		key  string
+		str  string
+	}{
+		{&is.BaseArrival, "BaseArrival", "base_arrival_time", is.BaseArrivalTime},
+		{&is.BaseDeparture, "BaseDeparture", "base_departure_time", is.BaseDepartureTime},
+		{&is.AmendedArrival, "AmendedArrival", "amended_arrival_time", is.AmendedArrivalTime},
+		{&is.AmendedDeparture, "AmendedDeparture", "amended_departure_time", is.AmendedDepartureTime},
+	}
+	for _, t := range times {
+		*t.dest, err = ParseServiceTime(t.str)
+		if err != nil {
+			return gen.err(err, t.name, t.key, t.str, "ParseServiceTime failed")
+		}
+	}
+
+	return nil
+}
 
-	ArrivalStatus string `json:"arrival_status"`
+// Delay returns the delay of the departure from the stop, or of the arrival if there's no departure.
+// It is 0 if the amended or base time is unknown.
+//
+// As the times are given modulo 24 hours, the amended time is taken within 12 hours of the base one,
+// so that a departure at 23:55 delayed to 00:05 is 10 minutes late.
+func (is ImpactedStop) Delay() time.Duration {
+	switch {
+	case is.AmendedDepartureTime != "" && is.BaseDepartureTime != "":
+		return time.Duration(is.AmendedDeparture.Unwrap(is.BaseDeparture) - is.BaseDeparture)
+	case is.AmendedArrivalTime != "" && is.BaseArrivalTime != "":
+		return time.Duration(is.AmendedArrival.Unwrap(is.BaseArrival) - is.BaseArrival)
+	default:
+		return 0
+	}
 }
@@ -4,6 +4,9 @@ package types
 // Two vehicles that serve exactly the same stop points in
 // exactly the same order belong to to the same journey pattern.
 type JourneyPattern struct {
-	ID   string
-	Name string
+	ID   ID     `json:"id"`
+	Name string `json:"name"`
+
+	// Route of the journey pattern, given with a depth of at least 2
+	Route Route `json:"route"`
 }
@@ -1,8 +1,12 @@
 package types
 
+// A Link is a link to another object, which may either be a reference to an object found in the response (through its ID)
+// or an URL.
 type Link struct {
+	ID        ID     `json:"id"`
 	Href      string `json:"href"`
 	Type      string `json:"type"`
 	Rel       string `json:"rel"`
 	Templated bool   `json:"templated"`
+	Internal  bool   `json:"internal"`
 }
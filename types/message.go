@@ -1,7 +1,14 @@
 package types
 
+import "strings"
+
 // A Message contains the text to be provided to the traveler.
 type Message struct {
 	Text    string   `json:"text"`    // The message to bring to the traveler
 	Channel *Channel `json:"channel"` // The destination media for this Message.
 }
+
+// IsHTML reports whether the message's text is HTML, as given by its channel's content type
+func (m Message) IsHTML() bool {
+	return m.Channel != nil && strings.HasPrefix(m.Channel.ContentType, "text/html")
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"image/color"
 	"strconv"
 	"time"
@@ -36,14 +37,26 @@ func parseColor(str string) (color.NRGBA, error) {
 		R: uint8(r),
 		G: uint8(g),
 		B: uint8(b),
+		A: 0xff,
 	}, nil
 }
 
+// HexColor formats a color as "#rrggbb", such as a Display.Color, or returns an empty string if it is nil.
+func HexColor(c color.Color) string {
+	if c == nil {
+		return ""
+	}
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
 const (
 	// DataFreshnessRealTime means you'll get undisrupted journeys
 	DataFreshnessRealTime DataFreshness = "realtime"
 	// DataFreshnessBaseSchedule means you can get disrupted journeys in the response.
-	DataFreshnessBaseSchedule = "base_schedule"
+	DataFreshnessBaseSchedule DataFreshness = "base_schedule"
+	// DataFreshnessAdaptedSchedule means the base schedule adapted to the known disruptions.
+	DataFreshnessAdaptedSchedule DataFreshness = "adapted_schedule"
 )
 
 // A PTDateTime (pt stands for “public transport”) is a complex date time object to manage the difference between stop and leaving times at a stop.
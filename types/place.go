@@ -52,6 +52,9 @@ type POI struct {
 
 	// The type of the POI
 	Type POIType `json:"poi_type"`
+
+	// Coordinates of the POI
+	Coord Coordinates `json:"coord"`
 }
 
 // An Address codes for a real-world address: a point located in a street.
@@ -83,6 +86,9 @@ type StopPoint struct {
 
 	Label string `json:"label"`
 
+	// Platform code of the stop point, if any
+	PlatformCode string `json:"platform_code"`
+
 	// Coordinates of the stop point
 	Coord Coordinates `json:"coord"`
 
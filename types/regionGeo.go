@@ -0,0 +1,139 @@
+package types
+
+import (
+	"math"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+)
+
+// Contains reports whether the coordinates are within the region's shape.
+// If the region has no shape (as when Geo data wasn't requested), Contains returns false.
+func (r *Region) Contains(c Coordinates) bool {
+	if r.Shape == nil {
+		return false
+	}
+
+	p := geom.Coord{c.Longitude, c.Latitude}
+	for i := 0; i < r.Shape.NumPolygons(); i++ {
+		if polygonContains(r.Shape.Polygon(i), p) {
+			return true
+		}
+	}
+	return false
+}
+
+// polygonContains reports whether the point is within the polygon's exterior ring, and outside of its holes.
+func polygonContains(polygon *geom.Polygon, p geom.Coord) bool {
+	if polygon.NumLinearRings() == 0 || !xy.IsPointInRing(geom.XY, p, polygon.LinearRing(0).FlatCoords()) {
+		return false
+	}
+	for j := 1; j < polygon.NumLinearRings(); j++ {
+		if xy.IsPointInRing(geom.XY, p, polygon.LinearRing(j).FlatCoords()) {
+			return false
+		}
+	}
+	return true
+}
+
+// BoundingBox returns the bounding box of the region's shape, with longitudes on the first dimension and latitudes on the second.
+// If the region has no shape, BoundingBox returns nil.
+func (r *Region) BoundingBox() *geom.Bounds {
+	if r.Shape == nil {
+		return nil
+	}
+	return r.Shape.Bounds()
+}
+
+// Centroid returns the centroid of the region's shape.
+// Note that for a concave region, the centroid may be outside of the region.
+// If the region has no shape, Centroid returns zero coordinates.
+func (r *Region) Centroid() Coordinates {
+	if r.Shape == nil || r.Shape.NumPolygons() == 0 {
+		return Coordinates{}
+	}
+	c := xy.MultiPolygonCentroid(r.Shape)
+	return Coordinates{Longitude: c.X(), Latitude: c.Y()}
+}
+
+// Area returns the area of the region's shape in square meters, computed on a spherical approximation of the Earth.
+// If the region has no shape, Area returns 0.
+func (r *Region) Area() float64 {
+	if r.Shape == nil {
+		return 0
+	}
+
+	var area float64
+	for i := 0; i < r.Shape.NumPolygons(); i++ {
+		polygon := r.Shape.Polygon(i)
+		for j := 0; j < polygon.NumLinearRings(); j++ {
+			ringArea := sphericalRingArea(polygon.LinearRing(j).FlatCoords())
+			// The first ring is the exterior one, the others are holes
+			if j == 0 {
+				area += ringArea
+			} else {
+				area -= ringArea
+			}
+		}
+	}
+	return area
+}
+
+// sphericalRingArea computes the area in square meters of a ring given as flat XY coordinates in degrees.
+//
+// See "Some Algorithms for Polygons on a Sphere" by Chamberlain & Duquette (JPL Publication 07-03).
+func sphericalRingArea(flat []float64) float64 {
+	n := len(flat) / 2
+	if n < 3 {
+		return 0
+	}
+
+	var sum float64
+	for i := 0; i < n; i++ {
+		lon1, lat1 := flat[2*i], flat[2*i+1]
+		lon2, lat2 := flat[2*((i+1)%n)], flat[2*((i+1)%n)+1]
+		sum += radians(lon2-lon1) * (2 + math.Sin(radians(lat1)) + math.Sin(radians(lat2)))
+	}
+	return math.Abs(sum * earthRadius * earthRadius / 2)
+}
+
+// DistanceTo returns the distance in meters between the coordinates and the region's shape.
+// If the coordinates are within the region, DistanceTo returns 0.
+// If the region has no shape, DistanceTo returns +Inf.
+//
+// The distance is computed on a local equirectangular projection centered on the coordinates,
+// which is precise enough for distances up to a few hundred kilometers.
+func (r *Region) DistanceTo(c Coordinates) float64 {
+	if r.Shape == nil {
+		return math.Inf(1)
+	}
+	if r.Contains(c) {
+		return 0
+	}
+
+	// Project each vertex relatively to c, in meters
+	cosLat := math.Cos(radians(c.Latitude))
+	project := func(lon, lat float64) geom.Coord {
+		return geom.Coord{
+			radians(lon-c.Longitude) * cosLat * earthRadius,
+			radians(lat-c.Latitude) * earthRadius,
+		}
+	}
+
+	origin := geom.Coord{0, 0}
+	min := math.Inf(1)
+	for i := 0; i < r.Shape.NumPolygons(); i++ {
+		polygon := r.Shape.Polygon(i)
+		for j := 0; j < polygon.NumLinearRings(); j++ {
+			flat := polygon.LinearRing(j).FlatCoords()
+			for k := 0; k+3 < len(flat); k += 2 {
+				start := project(flat[k], flat[k+1])
+				end := project(flat[k+2], flat[k+3])
+				if d := xy.DistanceFromPointToLine(origin, start, end); d < min {
+					min = d
+				}
+			}
+		}
+	}
+	return min
+}
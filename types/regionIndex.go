@@ -0,0 +1,165 @@
+package types
+
+import (
+	"math"
+	"sort"
+)
+
+// regionIndexNodeCapacity is the maximum amount of children of a node in a RegionIndex
+const regionIndexNodeCapacity = 8
+
+// A RegionIndex answers "which regions cover this point" efficiently, even for hundreds of regions.
+//
+// It is a static R-tree over the bounding boxes of the regions, bulk-loaded using the Sort-Tile-Recursive algorithm.
+// Candidates found through the tree are then checked against the regions' shapes.
+// As it is immutable once created, it is safe for concurrent use.
+type RegionIndex struct {
+	regions []Region
+	root    *regionIndexNode
+}
+
+// A regionIndexNode is a node of the R-tree.
+// Leaf entries have no children, and refer to a region through its index.
+type regionIndexNode struct {
+	box      boundingBox
+	children []*regionIndexNode
+	region   int
+}
+
+// boundingBox is a lightweight bounding box, longitudes as x and latitudes as y
+type boundingBox struct {
+	minX, minY, maxX, maxY float64
+}
+
+// contains reports whether the point is within the bounding box
+func (b boundingBox) contains(x, y float64) bool {
+	return x >= b.minX && x <= b.maxX && y >= b.minY && y <= b.maxY
+}
+
+// union returns the smallest bounding box containing both
+func (b boundingBox) union(other boundingBox) boundingBox {
+	return boundingBox{
+		minX: math.Min(b.minX, other.minX),
+		minY: math.Min(b.minY, other.minY),
+		maxX: math.Max(b.maxX, other.maxX),
+		maxY: math.Max(b.maxY, other.maxY),
+	}
+}
+
+// center returns the center of the bounding box
+func (b boundingBox) center() (x, y float64) {
+	return (b.minX + b.maxX) / 2, (b.minY + b.maxY) / 2
+}
+
+// NewRegionIndex creates a RegionIndex over the given regions.
+// Regions without a shape are kept, but never returned by queries.
+func NewRegionIndex(regions []Region) *RegionIndex {
+	idx := &RegionIndex{regions: regions}
+
+	// Create the leaf entries
+	entries := make([]*regionIndexNode, 0, len(regions))
+	for i := range regions {
+		bounds := regions[i].BoundingBox()
+		if bounds == nil || bounds.IsEmpty() {
+			continue
+		}
+		entries = append(entries, &regionIndexNode{
+			box:    boundingBox{minX: bounds.Min(0), minY: bounds.Min(1), maxX: bounds.Max(0), maxY: bounds.Max(1)},
+			region: i,
+		})
+	}
+	if len(entries) == 0 {
+		return idx
+	}
+
+	// Pack them level by level until only the root is left
+	level := entries
+	for len(level) > regionIndexNodeCapacity {
+		level = strPack(level)
+	}
+	idx.root = newRegionIndexParent(level)
+
+	return idx
+}
+
+// newRegionIndexParent creates a node holding the given children
+func newRegionIndexParent(children []*regionIndexNode) *regionIndexNode {
+	box := children[0].box
+	for _, c := range children[1:] {
+		box = box.union(c.box)
+	}
+	return &regionIndexNode{box: box, children: children}
+}
+
+// strPack packs a level of nodes into their parents using the Sort-Tile-Recursive algorithm:
+// the nodes are sorted by x, cut in vertical slices, and each slice is sorted by y then cut into parents.
+func strPack(nodes []*regionIndexNode) []*regionIndexNode {
+	parentsCount := int(math.Ceil(float64(len(nodes)) / regionIndexNodeCapacity))
+	slicesCount := int(math.Ceil(math.Sqrt(float64(parentsCount))))
+	sliceSize := slicesCount * regionIndexNodeCapacity
+
+	sort.Slice(nodes, func(i, j int) bool {
+		xi, _ := nodes[i].box.center()
+		xj, _ := nodes[j].box.center()
+		return xi < xj
+	})
+
+	parents := make([]*regionIndexNode, 0, parentsCount)
+	for start := 0; start < len(nodes); start += sliceSize {
+		slice := nodes[start:minInt(start+sliceSize, len(nodes))]
+		sort.Slice(slice, func(i, j int) bool {
+			_, yi := slice[i].box.center()
+			_, yj := slice[j].box.center()
+			return yi < yj
+		})
+		for k := 0; k < len(slice); k += regionIndexNodeCapacity {
+			parents = append(parents, newRegionIndexParent(slice[k:minInt(k+regionIndexNodeCapacity, len(slice))]))
+		}
+	}
+	return parents
+}
+
+// minInt returns the minimum of two ints
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Covering returns the regions whose shape contains the given coordinates, in the order they were given to NewRegionIndex.
+func (idx *RegionIndex) Covering(c Coordinates) []Region {
+	if idx.root == nil {
+		return nil
+	}
+
+	var found []int
+	var search func(n *regionIndexNode)
+	search = func(n *regionIndexNode) {
+		if !n.box.contains(c.Longitude, c.Latitude) {
+			return
+		}
+		if n.children == nil {
+			if idx.regions[n.region].Contains(c) {
+				found = append(found, n.region)
+			}
+			return
+		}
+		for _, child := range n.children {
+			search(child)
+		}
+	}
+	search(idx.root)
+
+	sort.Ints(found)
+	regions := make([]Region, len(found))
+	for i, n := range found {
+		regions[i] = idx.regions[n]
+	}
+	return regions
+}
+
+// Regions returns the regions indexed.
+func (idx *RegionIndex) Regions() []Region {
+	return idx.regions
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+)
+
+// TestRegionIndex_Covering checks that the index finds the regions covering known places
+func TestRegionIndex_Covering(t *testing.T) {
+	corpus := testData["region"].correct
+	if len(corpus) == 0 {
+		t.Skip("No data to test")
+	}
+
+	regions := make([]Region, 0, len(corpus))
+	for name, datum := range corpus {
+		r := Region{}
+		if err := r.UnmarshalJSON(datum); err != nil {
+			t.Fatalf("error while unmarshalling %s: %v", name, err)
+		}
+		regions = append(regions, r)
+	}
+	idx := NewRegionIndex(regions)
+
+	tests := []struct {
+		name   string
+		coords Coordinates
+		region ID
+	}{
+		{"paris", Coordinates{Longitude: 2.3522, Latitude: 48.8566}, "fr-idf"},
+		{"buenos aires", Coordinates{Longitude: -58.3816, Latitude: -34.6037}, "ar"},
+		{"atlantic", Coordinates{Longitude: -30, Latitude: 40}, ""},
+	}
+	for _, tt := range tests {
+		covering := idx.Covering(tt.coords)
+		if tt.region == "" {
+			if len(covering) != 0 {
+				t.Errorf("%s: expected no region, got %d", tt.name, len(covering))
+			}
+			continue
+		}
+
+		found := false
+		for _, r := range covering {
+			if r.ID == tt.region {
+				found = true
+			}
+			if !r.Contains(tt.coords) {
+				t.Errorf("%s: region %s returned yet doesn't contain %v", tt.name, r.ID, tt.coords)
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected region %s to be found", tt.name, tt.region)
+		}
+	}
+}
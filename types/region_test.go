@@ -69,3 +69,40 @@ func BenchmarkRegionUnmarshal(b *testing.B) {
 		b.Run(name, runFunc)
 	}
 }
+
+// TestRegion_Geometry tests the geometry helpers of Region on a simple square
+func TestRegion_Geometry(t *testing.T) {
+	r := &Region{}
+	if err := r.UnmarshalJSON([]byte(`{"shape": "MULTIPOLYGON(((2 48,3 48,3 49,2 49,2 48)))"}`)); err != nil {
+		t.Fatalf("error while unmarshalling region: %v", err)
+	}
+
+	inside := Coordinates{Longitude: 2.35, Latitude: 48.86}
+	outside := Coordinates{Longitude: 4, Latitude: 48.5}
+
+	if !r.Contains(inside) {
+		t.Errorf("expected %v to be within the region", inside)
+	}
+	if r.Contains(outside) {
+		t.Errorf("expected %v to be outside of the region", outside)
+	}
+	if c := r.Centroid(); c.Longitude != 2.5 || c.Latitude != 48.5 {
+		t.Errorf("unexpected centroid: %v", c)
+	}
+	if b := r.BoundingBox(); b.Min(0) != 2 || b.Max(1) != 49 {
+		t.Errorf("unexpected bounding box: %v", b)
+	}
+
+	// A 1°x1° square at this latitude is roughly 8200 km²
+	if area := r.Area() / 1e6; area < 8000 || area > 8400 {
+		t.Errorf("unexpected area: %f km²", area)
+	}
+
+	if d := r.DistanceTo(inside); d != 0 {
+		t.Errorf("expected a null distance for a point within the region, got %f", d)
+	}
+	// One degree of longitude at this latitude is roughly 73 km
+	if d := r.DistanceTo(outside) / 1e3; d < 72 || d > 75 {
+		t.Errorf("unexpected distance: %f km", d)
+	}
+}
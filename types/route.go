@@ -3,6 +3,9 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/geojson"
 )
 
 // A Route represents a route: a Line can have several routes,
@@ -15,33 +18,35 @@ type Route struct {
 	Line          Line           `json:"line"`           // Line is the line it is connected to
 	Direction     Container      `json:"direction"`      // Direction is the direction of the route (Place or POI)
 	PhysicalModes []PhysicalMode `json:"physical_modes"` // PhysicalModes of the line
-	GeoJSON       GeoJSON        `json:"geo_json"`
+
+	// Geo is the shape of the route, when requested with Geo data.
+	// It is nil when the route has no shape.
+	Geo *geom.MultiLineString `json:"geojson"`
 }
 
 // jsonRoute define the JSON implementation of Route struct
 // We define some of the value as pointers to the real values,
 // allowing us to bypass copying in cases where we don't need to process the data.
 type jsonRoute struct {
-	ID        *ID        `json:"id"`
-	Name      *string    `json:"name"`
-	Line      *Line      `json:"line"`
-	Direction *Container `json:"direction"`
+	ID            *ID             `json:"id"`
+	Name          *string         `json:"name"`
+	Line          *Line           `json:"line"`
+	Direction     *Container      `json:"direction"`
+	PhysicalModes *[]PhysicalMode `json:"physical_modes"`
 
 	// Value to process
-	Frequence string `json:"is_frequence"`
-}
-
-type GeoJSON struct {
-	Type string `json:"type"`
+	Frequence string            `json:"is_frequence"`
+	Geo       *geojson.Geometry `json:"geojson"`
 }
 
 // UnmarshalJSON implements json.Unmarshaller for Route
 func (r *Route) UnmarshalJSON(b []byte) error {
 	data := &jsonRoute{
-		ID:        &r.ID,
-		Name:      &r.Name,
-		Line:      &r.Line,
-		Direction: &r.Direction,
+		ID:            &r.ID,
+		Name:          &r.Name,
+		Line:          &r.Line,
+		Direction:     &r.Direction,
+		PhysicalModes: &r.PhysicalModes,
 	}
 
 	// Create the error generator
@@ -50,7 +55,7 @@ func (r *Route) UnmarshalJSON(b []byte) error {
 	// Now unmarshall the raw data into the analogous structure
 	err := json.Unmarshal(b, data)
 	if err != nil {
-		return fmt.Errorf("error while unmarshalling Line")
+		return fmt.Errorf("error while unmarshalling Route: %w", err)
 	}
 
 	// Now process the value
@@ -63,5 +68,27 @@ func (r *Route) UnmarshalJSON(b []byte) error {
 		return gen.err(nil, "Frequence", "is_frequency", data.Frequence, `String is neither True, true, False or false`)
 	}
 
+	// Now let's deal with the shape, a MultiLineString which may be empty
+	if data.Geo != nil && data.Geo.Coordinates != nil {
+		geot, err := data.Geo.Decode()
+		if err != nil {
+			return gen.err(err, "Geo", "geojson", data.Geo, "Geo.Decode() failed")
+		}
+
+		switch geo := geot.(type) {
+		case *geom.MultiLineString:
+			if geo.NumLineStrings() != 0 {
+				r.Geo = geo
+			}
+		case *geom.LineString:
+			r.Geo = geom.NewMultiLineString(geo.Layout())
+			if err := r.Geo.Push(geo); err != nil {
+				return gen.err(err, "Geo", "geojson", data.Geo, "MultiLineString.Push failed")
+			}
+		default:
+			return gen.err(nil, "Geo", "geojson", data.Geo, fmt.Sprintf("unexpected geometry %T, expected a MultiLineString", geot))
+		}
+	}
+
 	return nil
 }
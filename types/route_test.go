@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -14,3 +15,31 @@ import (
 func Test_Route_Unmarshal(t *testing.T) {
 	testUnmarshal(t, testData["route"], reflect.TypeOf(Route{}))
 }
+
+// Test_Route_Unmarshal_Geo checks that the shape of a route is decoded when given
+func Test_Route_Unmarshal_Geo(t *testing.T) {
+	tests := []struct {
+		file     string
+		segments int
+		points   int
+	}{
+		{"with_shape.json", 1, 8},
+		{"empty_shape.json", 0, 0},
+		{"doc.json", 0, 0},
+	}
+	for _, test := range tests {
+		var r Route
+		if err := json.Unmarshal(testData["route"].correct[test.file], &r); err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.file, err)
+		}
+		if test.segments == 0 {
+			if r.Geo != nil {
+				t.Errorf("%s: expected no shape, got %v", test.file, r.Geo.Coords())
+			}
+			continue
+		}
+		if r.Geo == nil || r.Geo.NumLineStrings() != test.segments || r.Geo.LineString(0).NumCoords() != test.points {
+			t.Errorf("%s: unexpected shape: %v", test.file, r.Geo)
+		}
+	}
+}
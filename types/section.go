@@ -24,6 +24,10 @@ type Section struct {
 	StopTimes  []StopTime       // List of the stop times of this section
 	Display    Display          // Information to display
 	Additional []PTMethod       // Additional informations, from what I can see this is always a PTMethod
+	Links      []Link           // Links to related objects, such as the vehicle journey of a public transport section
+
+	// Disruptions impacting this section, resolved from the disruptions of the results by Journey.ResolveDisruptions
+	Disruptions []Disruption
 }
 
 // jsonSection define the JSON implementation of Section struct
@@ -40,6 +44,7 @@ type jsonSection struct {
 	Display    *Display       `json:"display_informations"`
 	Additional *[]PTMethod    `json:"additional_informations"`
 	Path       *[]PathSegment `json:"path"`
+	Links      *[]Link        `json:"links"`
 
 	// Values to process
 	Departure string            `json:"departure_date_time"`
@@ -104,17 +109,121 @@ var SectionTypes = map[SectionType]string{
 	SectionLanding:           "Landing off the plane",
 }
 
+// VehicleJourneyID returns the ID of the vehicle journey taken in a public transport section, or an empty ID if there's none.
+func (s Section) VehicleJourneyID() ID {
+	for _, l := range s.Links {
+		if l.Type == "vehicle_journey" {
+			return l.ID
+		}
+	}
+	return ""
+}
+
 // A StopTime stores info about a stop in a route: when the vehicle comes in, when it comes out, and what stop it is.
+//
+// In a section, the stop times are dated and given through PTDateTime.
+// In a vehicle journey, they are times of day on the service day of the vehicle journey, given through the ServiceTime fields.
 type StopTime struct {
-	// The PTDateTime of the stop, this stores the info about the arrival & departure
-	PTDateTime       PTDateTime
-	StopPoint        StopPoint `json:"stop_point"` // The stop point in question
-	DropOffAllowed   bool      `json:"drop_off_allowed"`
-	UTCDepartureTime string    `json:"utc_departure_time"`
-	Headsign         string    `json:"headsign"`
-	UTCArrivalTime   string    `json:"utc_arrival_time"`
-	PickupAllowed    bool      `json:"pickup_allowed"`
-	DepartureTime    string    `json:"departure_time"`
+	// The PTDateTime of the stop, this stores the info about the arrival & departure.
+	// It is located in the time zone of the stop point when known.
+	PTDateTime     PTDateTime
+	StopPoint      StopPoint `json:"stop_point"` // The stop point in question
+	DropOffAllowed bool      `json:"drop_off_allowed"`
+	PickupAllowed  bool      `json:"pickup_allowed"`
+	Headsign       string    `json:"headsign"`
+
+	// Times of day of the arrival & departure, local and UTC.
+	// They may exceed 24 hours for a vehicle journey running past midnight.
+	Arrival      ServiceTime
+	Departure    ServiceTime
+	UTCArrival   ServiceTime
+	UTCDeparture ServiceTime
+
+	// Raw times, as given by the server
+	ArrivalTime       string `json:"arrival_time"`
+	DepartureTime     string `json:"departure_time"`
+	UTCArrivalTime    string `json:"utc_arrival_time"`
+	UTCDepartureTime  string `json:"utc_departure_time"`
+	ArrivalDateTime   string `json:"arrival_date_time"`
+	DepartureDateTime string `json:"departure_date_time"`
+}
+
+// jsonStopTime define the JSON implementation of StopTime struct
+// We define some of the value as pointers to the real values,
+// allowing us to bypass copying in cases where we don't need to process the data.
+type jsonStopTime struct {
+	StopPoint      *StopPoint `json:"stop_point"`
+	DropOffAllowed *bool      `json:"drop_off_allowed"`
+	PickupAllowed  *bool      `json:"pickup_allowed"`
+	Headsign       *string    `json:"headsign"`
+
+	// Values to process, while keeping the raw value
+	ArrivalTime       *string `json:"arrival_time"`
+	DepartureTime     *string `json:"departure_time"`
+	UTCArrivalTime    *string `json:"utc_arrival_time"`
+	UTCDepartureTime  *string `json:"utc_departure_time"`
+	ArrivalDateTime   *string `json:"arrival_date_time"`
+	DepartureDateTime *string `json:"departure_date_time"`
+}
+
+// UnmarshalJSON implements json.Unmarshaller for a StopTime
+func (st *StopTime) UnmarshalJSON(b []byte) error {
+	data := &jsonStopTime{
+		StopPoint:         &st.StopPoint,
+		DropOffAllowed:    &st.DropOffAllowed,
+		PickupAllowed:     &st.PickupAllowed,
+		Headsign:          &st.Headsign,
+		ArrivalTime:       &st.ArrivalTime,
+		DepartureTime:     &st.DepartureTime,
+		UTCArrivalTime:    &st.UTCArrivalTime,
+		UTCDepartureTime:  &st.UTCDepartureTime,
+		ArrivalDateTime:   &st.ArrivalDateTime,
+		DepartureDateTime: &st.DepartureDateTime,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return fmt.Errorf("error while unmarshalling StopTime: %w", err)
+	}
+
+	// Create the error generator
+	gen := unmarshalErrorMaker{"StopTime", b}
+
+	// The dated times are those of a PTDateTime
+	err = json.Unmarshal(b, &st.PTDateTime)
+	if err != nil {
+		return gen.err(err, "PTDateTime", "", nil, "error while unmarshalling PTDateTime")
+	}
+	st.Localize(st.StopPoint.TimeLocation())
+
+	// Now the times of day
+	times := []struct {
+		dest *ServiceTime
+		name string
+		key  string
+		str  string
+	}{
+		{&st.Arrival, "Arrival", "arrival_time", st.ArrivalTime},
+		{&st.Departure, "Departure", "departure_time", st.DepartureTime},
+		{&st.UTCArrival, "UTCArrival", "utc_arrival_time", st.UTCArrivalTime},
+		{&st.UTCDeparture, "UTCDeparture", "utc_departure_time", st.UTCDepartureTime},
+	}
+	for _, t := range times {
+		*t.dest, err = ParseServiceTime(t.str)
+		if err != nil {
+			return gen.err(err, t.name, t.key, t.str, "ParseServiceTime failed")
+		}
+	}
+
+	return nil
+}
+
+// Localize locates the dated times of the stop in the given time zone, keeping their wall clock.
+// A nil location leaves the times unchanged.
+func (st *StopTime) Localize(loc *time.Location) {
+	st.PTDateTime.Departure = InLocation(st.PTDateTime.Departure, loc)
+	st.PTDateTime.Arrival = InLocation(st.PTDateTime.Arrival, loc)
 }
 
 // A PTMethod is a Public Transportation method: it can be regular, estimated times or ODT (on-demand transport)
@@ -156,6 +265,7 @@ func (s *Section) UnmarshalJSON(b []byte) error {
 		Additional: &s.Additional,
 		StopTimes:  &s.StopTimes,
 		Path:       &s.Path,
+		Links:      &s.Links,
 	}
 
 	// Now unmarshall the raw data into the analogous structure
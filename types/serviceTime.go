@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ServiceTimeFormat is the format of the times of day used by the Navitia API, such as in vehicle journeys' stop times.
+const ServiceTimeFormat = "150405" // hhmmss
+
+// A ServiceTime is a time of day on a service day, as an offset from the day's midnight.
+//
+// As a vehicle journey running after midnight still belongs to the service day it started on,
+// a ServiceTime may exceed 24 hours: 25:30:00 is 01:30 on the following day.
+type ServiceTime time.Duration
+
+// ParseServiceTime parses a time of day formatted as hhmmss, the hours possibly exceeding 24.
+// If the given string is empty, ParseServiceTime returns 0.
+func ParseServiceTime(str string) (ServiceTime, error) {
+	if str == "" {
+		return 0, nil
+	}
+	if len(str) < len(ServiceTimeFormat) {
+		return 0, errors.Errorf("ParseServiceTime: invalid time %q: too short", str)
+	}
+
+	// The hours may have more than two digits, so split from the end
+	hh, mm, ss := str[:len(str)-4], str[len(str)-4:len(str)-2], str[len(str)-2:]
+	var parts [3]int
+	for i, s := range []string{hh, mm, ss} {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return 0, errors.Errorf("ParseServiceTime: invalid time %q", str)
+		}
+		parts[i] = n
+	}
+	if parts[1] > 59 || parts[2] > 59 {
+		return 0, errors.Errorf("ParseServiceTime: invalid time %q: minutes or seconds out of range", str)
+	}
+
+	d := time.Duration(parts[0])*time.Hour + time.Duration(parts[1])*time.Minute + time.Duration(parts[2])*time.Second
+	return ServiceTime(d), nil
+}
+
+// On returns the time at which the ServiceTime occurs on the given service day, in the day's location.
+// Only the date of the given day is taken into account.
+//
+// Following the GTFS convention, the offset is applied from noon minus 12 hours, so that it stays correct on DST change days.
+func (st ServiceTime) On(day time.Time) time.Time {
+	noon := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, day.Location())
+	return noon.Add(-12 * time.Hour).Add(time.Duration(st))
+}
+
+// Days returns the amount of whole days by which the ServiceTime exceeds its service day.
+func (st ServiceTime) Days() int {
+	return int(time.Duration(st) / (24 * time.Hour))
+}
+
+// Unwrap adds or removes days to a time of day given modulo 24 hours, such as an amended time, until it's within 12 hours of the reference.
+func (st ServiceTime) Unwrap(ref ServiceTime) ServiceTime {
+	const day = ServiceTime(24 * time.Hour)
+	for ref-st > day/2 {
+		st += day
+	}
+	for st-ref > day/2 {
+		st -= day
+	}
+	return st
+}
+
+// String returns the ServiceTime formatted as hh:mm:ss, the hours possibly exceeding 24.
+func (st ServiceTime) String() string {
+	d := time.Duration(st)
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	h := d / time.Hour
+	m := (d % time.Hour) / time.Minute
+	s := (d % time.Minute) / time.Second
+	return fmt.Sprintf("%s%02d:%02d:%02d", sign, h, m, s)
+}
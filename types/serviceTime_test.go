@@ -0,0 +1,143 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestParseServiceTime checks the parsing of times of day, including those past midnight
+func TestParseServiceTime(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "", want: "00:00:00"},
+		{in: "081530", want: "08:15:30"},
+		{in: "253000", want: "25:30:00"},
+		{in: "1000000", want: "100:00:00"},
+		{in: "0860", wantErr: true},
+		{in: "086000", wantErr: true},
+		{in: "08h15m", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseServiceTime(test.in)
+		switch {
+		case test.wantErr && err == nil:
+			t.Errorf("ParseServiceTime(%q): expected an error, got %v", test.in, got)
+		case !test.wantErr && err != nil:
+			t.Errorf("ParseServiceTime(%q): unexpected error: %v", test.in, err)
+		case !test.wantErr && got.String() != test.want:
+			t.Errorf("ParseServiceTime(%q): got %s, want %s", test.in, got, test.want)
+		}
+	}
+}
+
+// TestServiceTime_On checks that a ServiceTime is placed on the right day, including on DST change days
+func TestServiceTime_On(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone unavailable: %v", err)
+	}
+
+	// The clocks go forward on the 26th of March 2017 in Paris
+	day := time.Date(2017, time.March, 26, 0, 0, 0, 0, paris)
+	st, _ := ParseServiceTime("083000")
+	if got, want := st.On(day), time.Date(2017, time.March, 26, 8, 30, 0, 0, paris); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	st, _ = ParseServiceTime("253000")
+	if got, want := st.On(day), time.Date(2017, time.March, 27, 1, 30, 0, 0, paris); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// TestServiceTime_Unwrap checks that times of day given modulo 24 hours are brought back next to their reference
+func TestServiceTime_Unwrap(t *testing.T) {
+	tests := []struct {
+		t, ref string
+		want   string
+	}{
+		{"003000", "235500", "24:30:00"},
+		{"235500", "243000", "23:55:00"},
+		{"010000", "253000", "25:00:00"},
+		{"233000", "001000", "-00:30:00"},
+		{"081500", "080000", "08:15:00"},
+	}
+	for _, test := range tests {
+		st, _ := ParseServiceTime(test.t)
+		ref, _ := ParseServiceTime(test.ref)
+		if got := st.Unwrap(ref).String(); got != test.want {
+			t.Errorf("%s unwrapped next to %s: got %s, want %s", test.t, test.ref, got, test.want)
+		}
+	}
+}
+
+// TestVehicleJourney_UnmarshalJSON_afterMidnight checks that the stop times of a vehicle journey running past midnight are kept in order
+func TestVehicleJourney_UnmarshalJSON_afterMidnight(t *testing.T) {
+	raw := []byte(`{
+		"id": "vehicle_journey:night",
+		"stop_times": [
+			{"arrival_time": "234500", "departure_time": "235000", "utc_arrival_time": "214500", "utc_departure_time": "215000"},
+			{"arrival_time": "235800", "departure_time": "000300", "utc_arrival_time": "215800", "utc_departure_time": "220300"},
+			{"arrival_time": "001500", "departure_time": "001500", "utc_arrival_time": "221500", "utc_departure_time": "221500"}
+		]
+	}`)
+
+	var vj VehicleJourney
+	err := json.Unmarshal(raw, &vj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"23:45:00", "23:50:00", "23:58:00", "24:03:00", "24:15:00", "24:15:00"}
+	var got []string
+	for _, st := range vj.StopTimes {
+		got = append(got, st.Arrival.String(), st.Departure.String())
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+
+	// The UTC times don't wrap, so they are left untouched
+	if last := vj.StopTimes[2]; last.UTCDeparture.String() != "22:15:00" || last.UTCDepartureTime != "221500" {
+		t.Errorf("UTC departure: got %s (raw %q)", last.UTCDeparture, last.UTCDepartureTime)
+	}
+}
+
+// TestVehicleJourney_UnmarshalJSON_missingTime checks that a missing time, which parses as midnight, doesn't shift the following ones
+func TestVehicleJourney_UnmarshalJSON_missingTime(t *testing.T) {
+	raw := []byte(`{
+		"id": "vehicle_journey:terminus",
+		"stop_times": [
+			{"departure_time": "081000", "utc_departure_time": "061000"},
+			{"arrival_time": "081500", "departure_time": "081600", "utc_arrival_time": "061500", "utc_departure_time": "061600"},
+			{"arrival_time": "082500", "utc_arrival_time": "062500"}
+		]
+	}`)
+
+	var vj VehicleJourney
+	err := json.Unmarshal(raw, &vj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The missing arrival at the first stop & departure from the last one are left unset
+	want := []string{"00:00:00", "08:10:00", "08:15:00", "08:16:00", "08:25:00", "00:00:00"}
+	var got []string
+	for _, st := range vj.StopTimes {
+		got = append(got, st.Arrival.String(), st.Departure.String())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if last := vj.StopTimes[2]; last.UTCArrival.String() != "06:25:00" {
+		t.Errorf("UTC arrival: got %s", last.UTCArrival)
+	}
+}
@@ -39,9 +39,8 @@ func (s *Severity) UnmarshalJSON(b []byte) error {
 	// First let's create the analogous structure
 	// We define some of the value as pointers to the real values, allowing us to bypass copying in cases where we don't need to process the data
 	data := &jsonSeverity{
-		Name:     &s.Name,
-		Priority: s.Priority,
-		Effect:   &s.Effect,
+		Name:   &s.Name,
+		Effect: &s.Effect,
 	}
 
 	// Let's create the error generator
@@ -53,6 +52,9 @@ func (s *Severity) UnmarshalJSON(b []byte) error {
 		return fmt.Errorf("error while unmarshalling Severity: %w", err)
 	}
 
+	// The priority is only allocated when given
+	s.Priority = data.Priority
+
 	// Process the color
 	if str := data.Color; len(str) == 6 {
 		clr, err := parseColor(str)
@@ -64,3 +66,44 @@ func (s *Severity) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// effectRanks ranks the effects from the least to the most severe
+var effectRanks = map[Effect]int{
+	JourneyStatusUnknownEffect:     1,
+	JourneyStatusOtherEffect:       2,
+	JourneyStatusAdditionalService: 3,
+	JourneyStatusModifiedService:   4,
+	JourneyStatusStopMoved:         5,
+	JourneyStatusDetour:            6,
+	JourneyStatusSignificantDelay:  7,
+	EffectSignificantDelays:        7,
+	JourneyStatusReducedService:    8,
+	EffectNoService:                9,
+}
+
+// Rank returns the severity rank of the effect, higher meaning more severe.
+// From the most to the least severe: no service, reduced service, significant delays, detour, stop moved,
+// modified service, additional service, other effect and unknown effect.
+// An empty or unknown effect ranks 0.
+func (e Effect) Rank() int {
+	return effectRanks[e]
+}
+
+// Compare compares the severities, returning a positive number if s is more severe than other, a negative one if it is less, and 0 if they're equal.
+//
+// Severities are ordered by effect first, see Effect.Rank, then by priority, a lower priority being more severe
+// and an undefined priority being less severe than any defined one. This is a total order, suitable for sorting.
+func (s Severity) Compare(other Severity) int {
+	if diff := s.Effect.Rank() - other.Effect.Rank(); diff != 0 {
+		return diff
+	}
+	switch {
+	case s.Priority == nil && other.Priority == nil:
+		return 0
+	case s.Priority == nil:
+		return -1
+	case other.Priority == nil:
+		return 1
+	}
+	return *other.Priority - *s.Priority
+}
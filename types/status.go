@@ -0,0 +1,148 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// A RegionStatus reports the status of the Navitia instance (kraken) serving a region, as given by /coverage/{region}/status.
+type RegionStatus struct {
+	RegionID ID     `json:"region_id"`
+	Status   string `json:"status"` // Status of the instance, such as "running"
+
+	KrakenVersion string `json:"kraken_version"` // Version of the kraken serving the region
+	DataVersion   int    `json:"data_version"`   // Version of the loaded data, incremented at each reload
+
+	Loaded         bool `json:"loaded"`                   // Is the data loaded ?
+	LastLoadStatus bool `json:"last_load_status"`         // Was the last load successful ?
+	RabbitMQ       bool `json:"is_connected_to_rabbitmq"` // Is the instance connected to its realtime message queue ?
+	RealtimeLoaded bool `json:"is_realtime_loaded"`       // Is realtime data loaded ?
+	OpenData       bool `json:"is_open_data"`
+	OpenService    bool `json:"is_open_service"`
+	Threads        int  `json:"nb_threads"` // Amount of threads of the instance
+
+	DatasetCreation      time.Time // When was the dataset created ?
+	PublicationDate      time.Time // When was the dataset published ?
+	LastLoaded           time.Time // When was the data last loaded ?
+	LastRealtimeLoaded   time.Time // When was the realtime data last loaded ?
+	ProductionStart      time.Time
+	ProductionEnd        time.Time
+	RealtimeProxies      []RealtimeProxy `json:"realtime_proxies"`      // Proxies providing realtime data
+	RealtimeContributors []string        `json:"realtime_contributors"` // Contributors of the realtime data
+	StreetNetworks       []StreetNetwork `json:"street_networks"`       // Backends computing the street network sections
+
+	// An error reported by the instance
+	Error string `json:"error"`
+}
+
+// jsonRegionStatus define the JSON implementation of RegionStatus struct
+// We define some of the value as pointers to the real values,
+// allowing us to bypass copying in cases where we don't need to process the data.
+type jsonRegionStatus struct {
+	RegionID             *ID              `json:"region_id"`
+	Status               *string          `json:"status"`
+	KrakenVersion        *string          `json:"kraken_version"`
+	DataVersion          *int             `json:"data_version"`
+	Loaded               *bool            `json:"loaded"`
+	LastLoadStatus       *bool            `json:"last_load_status"`
+	RabbitMQ             *bool            `json:"is_connected_to_rabbitmq"`
+	RealtimeLoaded       *bool            `json:"is_realtime_loaded"`
+	OpenData             *bool            `json:"is_open_data"`
+	OpenService          *bool            `json:"is_open_service"`
+	Threads              *int             `json:"nb_threads"`
+	RealtimeProxies      *[]RealtimeProxy `json:"realtime_proxies"`
+	RealtimeContributors *[]string        `json:"realtime_contributors"`
+	StreetNetworks       *[]StreetNetwork `json:"street_networks"`
+	Error                *string          `json:"error"`
+
+	// Values to process
+	DatasetCreation    string `json:"dataset_created_at"`
+	PublicationDate    string `json:"publication_date"`
+	LastLoaded         string `json:"last_load_at"`
+	LastRealtimeLoaded string `json:"last_rt_data_loaded"`
+	ProductionStart    string `json:"start_production_date"`
+	ProductionEnd      string `json:"end_production_date"`
+}
+
+// A RealtimeProxy is a connector providing realtime data to a region.
+type RealtimeProxy struct {
+	ID    string `json:"id"`
+	Class string `json:"class"` // Implementation of the connector
+
+	CircuitBreaker struct {
+		State       string `json:"current_state"` // Either "closed", "open" or "half-open"
+		FailCounter int    `json:"fail_counter"`
+	} `json:"circuit_breaker"`
+}
+
+// A StreetNetwork is a backend computing street network sections for some modes.
+type StreetNetwork struct {
+	Class string   `json:"class"` // Implementation of the backend
+	Modes []string `json:"modes"` // Modes handled by this backend
+}
+
+// A GeoStatus reports the geographical data loaded for a region, as given by /coverage/{region}/_geo_status.
+type GeoStatus struct {
+	StreetNetworkSources []string `json:"street_network_sources"` // Sources of the street network, such as "osm"
+	POISources           []string `json:"poi_sources"`            // Sources of the points of interest
+
+	Admins           int `json:"nb_admins"`
+	AdminsFromCities int `json:"nb_admins_from_cities"`
+	Ways             int `json:"nb_ways"`
+	Addresses        int `json:"nb_addresses"`
+	POIs             int `json:"nb_pois"`
+}
+
+// UnmarshalJSON implements json.Unmarshaller for a RegionStatus
+func (rs *RegionStatus) UnmarshalJSON(b []byte) error {
+	data := &jsonRegionStatus{
+		RegionID:             &rs.RegionID,
+		Status:               &rs.Status,
+		KrakenVersion:        &rs.KrakenVersion,
+		DataVersion:          &rs.DataVersion,
+		Loaded:               &rs.Loaded,
+		LastLoadStatus:       &rs.LastLoadStatus,
+		RabbitMQ:             &rs.RabbitMQ,
+		RealtimeLoaded:       &rs.RealtimeLoaded,
+		OpenData:             &rs.OpenData,
+		OpenService:          &rs.OpenService,
+		Threads:              &rs.Threads,
+		RealtimeProxies:      &rs.RealtimeProxies,
+		RealtimeContributors: &rs.RealtimeContributors,
+		StreetNetworks:       &rs.StreetNetworks,
+		Error:                &rs.Error,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return fmt.Errorf("error while unmarshalling RegionStatus: %w", err)
+	}
+
+	// Create the error generator
+	gen := unmarshalErrorMaker{"RegionStatus", b}
+
+	// Now process the times
+	times := []struct {
+		dest *time.Time
+		name string
+		key  string
+		str  string
+	}{
+		{&rs.DatasetCreation, "DatasetCreation", "dataset_created_at", data.DatasetCreation},
+		{&rs.PublicationDate, "PublicationDate", "publication_date", data.PublicationDate},
+		{&rs.LastLoaded, "LastLoaded", "last_load_at", data.LastLoaded},
+		{&rs.LastRealtimeLoaded, "LastRealtimeLoaded", "last_rt_data_loaded", data.LastRealtimeLoaded},
+		{&rs.ProductionStart, "ProductionStart", "start_production_date", data.ProductionStart},
+		{&rs.ProductionEnd, "ProductionEnd", "end_production_date", data.ProductionEnd},
+	}
+	for _, t := range times {
+		*t.dest, err = parseDateTime(t.str)
+		if err != nil {
+			return gen.err(err, t.name, t.key, t.str, "parseDateTime failed")
+		}
+	}
+
+	return nil
+}
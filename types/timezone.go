@@ -0,0 +1,94 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// locations caches the time zones loaded by loadLocation, as loading them reads the system's zoneinfo each time
+var locations sync.Map
+
+// loadLocation returns the time zone with the given IANA name, or nil if it is empty or unknown.
+func loadLocation(name string) *time.Location {
+	if name == "" {
+		return nil
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil
+	}
+	locations.Store(name, loc)
+	return loc
+}
+
+// InLocation returns the time with the same wall clock as t, in the given location.
+// As Navitia's datetimes are wall-clock times without any offset, this is what gives them their actual instant.
+// A zero time or a nil location leave t unchanged.
+func InLocation(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() || loc == nil {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// TimeLocation returns the time zone of the stop point, as given by its stop area.
+// It returns nil if the stop area or its time zone is unknown.
+func (sp StopPoint) TimeLocation() *time.Location {
+	if sp.StopArea == nil {
+		return nil
+	}
+	return loadLocation(sp.StopArea.Timezone)
+}
+
+// A Context holds the context of a response: the time zone of the region and the current datetime of the server.
+type Context struct {
+	// Time zone of the region, such as "Europe/Paris"
+	Timezone string `json:"timezone"`
+
+	// Current datetime of the server, in the region's time zone
+	CurrentDateTime time.Time
+}
+
+// jsonContext define the JSON implementation of Context struct
+// We define some of the value as pointers to the real values,
+// allowing us to bypass copying in cases where we don't need to process the data.
+type jsonContext struct {
+	Timezone *string `json:"timezone"`
+
+	// Values to process
+	CurrentDateTime string `json:"current_datetime"`
+}
+
+// UnmarshalJSON implements json.Unmarshaller for a Context
+func (c *Context) UnmarshalJSON(b []byte) error {
+	data := &jsonContext{
+		Timezone: &c.Timezone,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return fmt.Errorf("error while unmarshalling Context: %w", err)
+	}
+
+	// Create the error generator
+	gen := unmarshalErrorMaker{"Context", b}
+
+	c.CurrentDateTime, err = parseDateTime(data.CurrentDateTime)
+	if err != nil {
+		return gen.err(err, "CurrentDateTime", "current_datetime", data.CurrentDateTime, "parseDateTime failed")
+	}
+	c.CurrentDateTime = InLocation(c.CurrentDateTime, c.Location())
+
+	return nil
+}
+
+// Location returns the time zone of the region, or nil if unknown.
+func (c Context) Location() *time.Location {
+	return loadLocation(c.Timezone)
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"testing"
 
 	"github.com/pkg/errors"
 )
@@ -15,8 +16,17 @@ var (
 	testDataPath     string
 )
 
-func init() {
+// TestMain parses the flags & loads the test data before running the tests.
+//
+// This can't be done in an init function, as the testing flags aren't registered yet at that point.
+func TestMain(m *testing.M) {
 	flag.Parse()
+	setupTestData()
+	os.Exit(m.Run())
+}
+
+// setupTestData loads the test data from the directory given by the path flag
+func setupTestData() {
 	// If the given path is absolute, then use it as-is
 	if filepath.IsAbs(*testDataPathFlag) {
 		testDataPath = *testDataPathFlag
@@ -1,6 +1,69 @@
 package types
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// A ValidityPattern gives the dates on which a vehicle journey runs, as a bitstring of days.
+//
+// The rightmost character of Days is the BeginningDate, each character on its left being the following day:
+// with a beginning date on monday, "0110" means the vehicle journey runs on tuesday & wednesday.
 type ValidityPattern struct {
-	BeginningDate string `json:"beginning_date"`
-	Days          string `json:"days"`
+	BeginningDate time.Time `json:"beginning_date"`
+	Days          string    `json:"days"`
+}
+
+// UnmarshalJSON implements json.Unmarshaller for a ValidityPattern
+func (vp *ValidityPattern) UnmarshalJSON(b []byte) error {
+	data := &struct {
+		BeginningDate string  `json:"beginning_date"`
+		Days          *string `json:"days"`
+	}{
+		Days: &vp.Days,
+	}
+
+	// Let's create the error generator
+	gen := unmarshalErrorMaker{"ValidityPattern", b}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return errors.Wrap(err, "Error while unmarshalling ValidityPattern")
+	}
+
+	vp.BeginningDate, err = parseDateTime(data.BeginningDate)
+	if err != nil {
+		return gen.err(err, "BeginningDate", "beginning_date", data.BeginningDate, "parseDateTime failed")
+	}
+
+	// Check the bitstring
+	if strings.Trim(vp.Days, "01") != "" {
+		return gen.err(nil, "Days", "days", vp.Days, "days should only hold 0s & 1s")
+	}
+
+	return nil
+}
+
+// IsZero reports whether the validity pattern is empty
+func (vp ValidityPattern) IsZero() bool {
+	return vp.Days == ""
+}
+
+// RunsOn reports whether the date is set in the validity pattern
+func (vp ValidityPattern) RunsOn(date time.Time) bool {
+	i := daysBetween(vp.BeginningDate, date)
+	if i < 0 || i >= len(vp.Days) {
+		return false
+	}
+	return vp.Days[len(vp.Days)-1-i] == '1'
+}
+
+// bounds returns the first & last dates covered by the validity pattern
+func (vp ValidityPattern) bounds() (first, last time.Time) {
+	first = civilDate(vp.BeginningDate)
+	return first, first.AddDate(0, 0, len(vp.Days)-1)
 }
@@ -1,8 +1,17 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
 // VehicleJourney gives informations on vehicle transportation schedule and details.
+//
+// Its StopTimes are given in the order they are served, with their times of day past midnight
+// when the vehicle journey runs overnight.
 type VehicleJourney struct {
-	ID              string          `json:"id"`
+	ID              ID              `json:"id"`
 	Name            string          `json:"name"`
 	Codes           []Code          `json:"codes"`
 	Disruptions     []Disruption    `json:"disruptions"`
@@ -13,3 +22,106 @@ type VehicleJourney struct {
 	Headsign        string          `json:"headsign"`
 	Trip            Trip            `json:"trip"`
 }
+
+// jsonVehicleJourney define the JSON implementation of VehicleJourney struct
+// We define some of the value as pointers to the real values,
+// allowing us to bypass copying in cases where we don't need to process the data.
+type jsonVehicleJourney struct {
+	ID              *ID              `json:"id"`
+	Name            *string          `json:"name"`
+	Codes           *[]Code          `json:"codes"`
+	Disruptions     *[]Disruption    `json:"disruptions"`
+	Calendars       *[]Calendar      `json:"calendars"`
+	StopTimes       *[]StopTime      `json:"stop_times"`
+	ValidityPattern *ValidityPattern `json:"validity_pattern"`
+	JourneyPattern  *JourneyPattern  `json:"journey_pattern"`
+	Headsign        *string          `json:"headsign"`
+	Trip            *Trip            `json:"trip"`
+}
+
+// UnmarshalJSON implements json.Unmarshaller for a VehicleJourney.
+//
+// The API gives the times of day of the stop times modulo 24 hours, so a vehicle journey running past midnight
+// goes from 23:55 to 00:05. These are brought back in order, 00:05 becoming 24:05 on the service day.
+func (vj *VehicleJourney) UnmarshalJSON(b []byte) error {
+	data := &jsonVehicleJourney{
+		ID:              &vj.ID,
+		Name:            &vj.Name,
+		Codes:           &vj.Codes,
+		Disruptions:     &vj.Disruptions,
+		Calendars:       &vj.Calendars,
+		StopTimes:       &vj.StopTimes,
+		ValidityPattern: &vj.ValidityPattern,
+		JourneyPattern:  &vj.JourneyPattern,
+		Headsign:        &vj.Headsign,
+		Trip:            &vj.Trip,
+	}
+
+	// Now unmarshall the raw data into the analogous structure
+	err := json.Unmarshal(b, data)
+	if err != nil {
+		return fmt.Errorf("error while unmarshalling VehicleJourney: %w", err)
+	}
+
+	// Put the times of day back in order, the local and UTC ones separately as they may not wrap at the same stop
+	vj.unwrapServiceTimes(
+		func(st *StopTime) (*ServiceTime, string) { return &st.Arrival, st.ArrivalTime },
+		func(st *StopTime) (*ServiceTime, string) { return &st.Departure, st.DepartureTime },
+	)
+	vj.unwrapServiceTimes(
+		func(st *StopTime) (*ServiceTime, string) { return &st.UTCArrival, st.UTCArrivalTime },
+		func(st *StopTime) (*ServiceTime, string) { return &st.UTCDeparture, st.UTCDepartureTime },
+	)
+
+	return nil
+}
+
+// unwrapServiceTimes makes the given arrival & departure times of the stop times non-decreasing,
+// by adding a day each time they go back in time.
+//
+// The getters return the parsed time along with its raw value: as a missing time parses as midnight,
+// the times whose raw value is empty are skipped.
+func (vj *VehicleJourney) unwrapServiceTimes(arrival, departure func(*StopTime) (*ServiceTime, string)) {
+	const day = ServiceTime(24 * time.Hour)
+
+	var offset, last ServiceTime
+	for i := range vj.StopTimes {
+		for _, get := range [...]func(*StopTime) (*ServiceTime, string){arrival, departure} {
+			t, raw := get(&vj.StopTimes[i])
+			if raw == "" {
+				continue
+			}
+			*t += offset
+			if *t < last {
+				offset += day
+				*t += day
+			}
+			last = *t
+		}
+	}
+}
+
+// Route returns the route of the vehicle journey, as given by its journey pattern
+func (vj VehicleJourney) Route() Route {
+	return vj.JourneyPattern.Route
+}
+
+// HeadsignAt returns the headsign displayed at the i-th stop time: its own headsign if any, or else the vehicle journey's.
+func (vj VehicleJourney) HeadsignAt(i int) string {
+	if i >= 0 && i < len(vj.StopTimes) && vj.StopTimes[i].Headsign != "" {
+		return vj.StopTimes[i].Headsign
+	}
+	return vj.Headsign
+}
+
+// HeadsignChanges returns the indexes of the stop times at which the displayed headsign changes, see HeadsignAt.
+// The first stop time isn't considered a change.
+func (vj VehicleJourney) HeadsignChanges() []int {
+	var changes []int
+	for i := 1; i < len(vj.StopTimes); i++ {
+		if vj.HeadsignAt(i) != vj.HeadsignAt(i-1) {
+			changes = append(changes, i)
+		}
+	}
+	return changes
+}
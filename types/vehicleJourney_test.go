@@ -0,0 +1,30 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestVehicleJourney_HeadsignChanges checks the headsigns displayed along a vehicle journey
+func TestVehicleJourney_HeadsignChanges(t *testing.T) {
+	vj := VehicleJourney{
+		Headsign: "KOHL",
+		StopTimes: []StopTime{
+			{Headsign: ""},
+			{Headsign: "KOHL"},
+			{Headsign: "PAPY"},
+			{Headsign: "PAPY"},
+			{Headsign: ""},
+		},
+	}
+
+	if got := vj.HeadsignAt(2); got != "PAPY" {
+		t.Errorf("HeadsignAt(2): got %q, want PAPY", got)
+	}
+	if got := vj.HeadsignAt(0); got != "KOHL" {
+		t.Errorf("HeadsignAt(0): got %q, want the vehicle journey's", got)
+	}
+	if got, want := vj.HeadsignChanges(), []int{2, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HeadsignChanges: got %v, want %v", got, want)
+	}
+}
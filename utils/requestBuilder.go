@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"time"
 
+	"golang.org/x/text/language"
+
 	"github.com/govitia/navitia/types"
 )
 
@@ -23,12 +25,12 @@ func (rb RequestBuilder) AddUInt(key string, amount uint) {
 	rb.params.Add(key, strconv.FormatUint(uint64(amount), 10))
 }
 
-// AddUInt add a signed integer to the request.
+// AddInt add a signed integer to the request.
 func (rb RequestBuilder) AddInt(key string, amount int) {
 	rb.params.Add(key, strconv.FormatInt(int64(amount), 10))
 }
 
-// AddUInt add a floating point number to the request.
+// AddFloat64 add a floating point number to the request.
 func (rb RequestBuilder) AddFloat64(key string, amount float64) {
 	rb.params.Add(key, strconv.FormatFloat(amount, 'f', 3, 64))
 }
@@ -74,7 +76,21 @@ func (rb RequestBuilder) AddDateTime(key string, date time.Time) {
 	}
 }
 
+// AddDate add a date to the request (YYYYMMDD)
+func (rb RequestBuilder) AddDate(key string, date time.Time) {
+	if !date.IsZero() {
+		rb.params.Add(key, date.Format(types.DateFormat))
+	}
+}
+
+// AddLanguage add a language to the request, such as "fr-FR", unless it is undefined
+func (rb RequestBuilder) AddLanguage(key string, lang language.Tag) {
+	if lang != language.Und {
+		rb.params.Add(key, lang.String())
+	}
+}
+
 // Values return value of url.Values
 func (rb RequestBuilder) Values() url.Values {
 	return *rb.params
-}
\ No newline at end of file
+}
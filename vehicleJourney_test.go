@@ -0,0 +1,105 @@
+package navitia
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/govitia/navitia/types"
+)
+
+// Test_VehicleJourneyResults_Unmarshal tests unmarshalling for VehicleJourneyResults.
+//
+// This launches both a "correct" and "incorrect" subtest, allowing us to test both cases.
+//
+//	If we expect no errors but we get one, the test fails
+//	If we expect an error but we don't get one, the test fails
+func Test_VehicleJourneyResults_Unmarshal(t *testing.T) {
+	testUnmarshal(t, testData["vehiclejourneys"], reflect.TypeOf(VehicleJourneyResults{}))
+}
+
+// Test_VehicleJourneyRequest_toURL checks that only the given parameters of a VehicleJourneyRequest are encoded
+func Test_VehicleJourneyRequest_toURL(t *testing.T) {
+	t.Parallel()
+
+	depth := uint(2)
+	req := VehicleJourneyRequest{
+		Since:     time.Date(2017, time.April, 27, 17, 0, 0, 0, time.UTC),
+		Until:     time.Date(2017, time.April, 27, 19, 0, 0, 0, time.UTC),
+		Headsign:  "KOHL",
+		Freshness: types.DataFreshnessRealTime,
+		Count:     5,
+		Depth:     &depth,
+		Geo:       true,
+	}
+	values, err := req.toURL()
+	if err != nil {
+		t.Fatalf("error in VehicleJourneyRequest.toURL: %v", err)
+	}
+
+	want := map[string]string{
+		"since":          "20170427T170000",
+		"until":          "20170427T190000",
+		"headsign":       "KOHL",
+		"data_freshness": "realtime",
+		"count":          "5",
+		"depth":          "2",
+	}
+	if len(values) != len(want) {
+		t.Errorf("expected %d parameters, got %v", len(want), values)
+	}
+	for key, value := range want {
+		if got := values.Get(key); got != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+}
+
+// TestScope_VehicleJourney checks that a vehicle journey is requested by ID, or through the object it belongs to,
+// and that its route & stop times are decoded
+func TestScope_VehicleJourney(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write(testData["vehiclejourneys"].correct["doc.json"])
+	}))
+	defer srv.Close()
+
+	s, err := NewCustom("key", srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("error in NewCustom: %v", err)
+	}
+	scope := s.Scope("fr-idf")
+	ctx := context.Background()
+
+	const id = "vehicle_journey:OIF:104011580-1_1028-1"
+	depth := uint(2)
+	res, err := scope.VehicleJourney(ctx, VehicleJourneyRequest{Depth: &depth}, id)
+	if err != nil {
+		t.Fatalf("error in VehicleJourney: %v", err)
+	}
+	if want := "/coverage/fr-idf/vehicle_journeys/" + id; path != want {
+		t.Errorf("VehicleJourney: expected request to %s, got %s", want, path)
+	}
+	if res.Count() != 1 {
+		t.Fatalf("expected one vehicle journey, got %d", res.Count())
+	}
+
+	vj := res.VehicleJourneys[0]
+	if vj.ID != id || vj.Route().ID != "route:OIF:810:BOIF820" || vj.Route().Line.Code != "B" {
+		t.Errorf("unexpected vehicle journey: %s on route %+v", vj.ID, vj.Route())
+	}
+	if len(vj.StopTimes) != 3 || vj.StopTimes[2].Arrival.String() != "24:27:00" || vj.StopTimes[0].DropOffAllowed {
+		t.Errorf("unexpected stop times: %+v", vj.StopTimes)
+	}
+
+	if _, err := scope.ObjectVehicleJourneys(ctx, VehicleJourneyRequest{}, "route:OIF:810:BOIF820"); err != nil {
+		t.Fatalf("error in ObjectVehicleJourneys: %v", err)
+	}
+	if want := "/coverage/fr-idf/routes/route:OIF:810:BOIF820/vehicle_journeys"; path != want {
+		t.Errorf("ObjectVehicleJourneys: expected request to %s, got %s", want, path)
+	}
+}
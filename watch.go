@@ -0,0 +1,243 @@
+package navitia
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/govitia/navitia/types"
+)
+
+// A DepartureEventType is the kind of change reported by a DepartureEvent
+type DepartureEventType int
+
+// DepartureXXX are the kinds of changes reported by WatchDepartures
+const (
+	// DepartureAdded is sent for a departure appearing on the board, including on the first poll
+	DepartureAdded DepartureEventType = iota
+
+	// DepartureDelayed is sent when the departure time changes, be it later or earlier
+	DepartureDelayed
+
+	// DepartureCancelled is sent when a departure disappears from the board before its departure time
+	DepartureCancelled
+
+	// DeparturePlatformChanged is sent when the vehicle leaves from another stop point or platform
+	DeparturePlatformChanged
+
+	// DepartureDeparted is sent when a departure disappears from the board after its departure time
+	DepartureDeparted
+
+	// DepartureError is sent when a poll fails, the watch goes on nonetheless
+	DepartureError
+)
+
+// departureEventTypeNames stores the names of the departure event types
+var departureEventTypeNames = map[DepartureEventType]string{
+	DepartureAdded:           "added",
+	DepartureDelayed:         "delayed",
+	DepartureCancelled:       "cancelled",
+	DeparturePlatformChanged: "platform changed",
+	DepartureDeparted:        "departed",
+	DepartureError:           "error",
+}
+
+// String returns the name of the event type
+func (t DepartureEventType) String() string {
+	if name, ok := departureEventTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// A DepartureEvent reports a change on a departure board watched through WatchDepartures.
+type DepartureEvent struct {
+	Type DepartureEventType
+
+	// The departure, as last seen
+	Connection Connection
+
+	// The previous state of the departure, for DepartureDelayed and DeparturePlatformChanged
+	Previous Connection
+
+	// The error which occurred, for DepartureError
+	Err error
+}
+
+const (
+	// watchDefaultInterval is the polling interval used when none is given
+	watchDefaultInterval = 30 * time.Second
+
+	// watchMaxBackoff is the maximum factor by which the polling interval grows when nothing changes
+	watchMaxBackoff = 4
+
+	// watchBackoffStep is the factor by which the polling interval grows after a poll without changes
+	watchBackoffStep = 1.5
+
+	// watchCount is the amount of departures requested on each poll
+	watchCount = 20
+)
+
+// WatchDepartures polls the departures of the given stop area or stop point, and reports their changes on the returned channel.
+//
+// Departures are followed by vehicle journey and stop area. On the first poll, every departure is reported as added.
+// When a poll brings no change, the polling interval grows up to four times the given one, going back to it as soon as something changes.
+// A failed poll is reported as a DepartureError event, and treated as a poll without changes.
+//
+// The channel is closed once the context is done. It must be drained, as polling waits for the events to be received.
+func (scope *Scope) WatchDepartures(ctx context.Context, stopID types.ID, interval time.Duration) (<-chan DepartureEvent, error) {
+	if t := stopID.Type(); t != "stop_area" && t != "stop_point" {
+		return nil, errors.Errorf("WatchDepartures: %q is neither a stop area nor a stop point", stopID)
+	}
+	if interval <= 0 {
+		interval = watchDefaultInterval
+	}
+
+	events := make(chan DepartureEvent)
+	go scope.watchDepartures(ctx, stopID, interval, events)
+	return events, nil
+}
+
+// watchDepartures is the polling loop of WatchDepartures
+func (scope *Scope) watchDepartures(ctx context.Context, stopID types.ID, interval time.Duration, events chan<- DepartureEvent) {
+	defer close(events)
+
+	req := ConnectionsRequest{
+		Count:     watchCount,
+		Freshness: types.DataFreshnessRealTime,
+	}
+	board := map[string]Connection{}
+	wait := interval
+
+	for {
+		var found []DepartureEvent
+		res, err := scope.Departures(ctx, req, stopID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			found = []DepartureEvent{{Type: DepartureError, Err: err}}
+		} else {
+			now := res.Context.CurrentDateTime
+			if now.IsZero() {
+				now = time.Now()
+				if loc := res.Context.Location(); loc != nil {
+					now = now.In(loc)
+				}
+			}
+			found, board = diffDepartures(board, res.Connections, len(res.Connections) < watchCount, now)
+		}
+
+		// Send the events
+		for _, e := range found {
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		// Adapt the interval: back off when nothing changed, reset otherwise
+		changed := len(found) != 0 && (err == nil)
+		if changed {
+			wait = interval
+		} else if next := time.Duration(float64(wait) * watchBackoffStep); next <= watchMaxBackoff*interval {
+			wait = next
+		} else {
+			wait = watchMaxBackoff * interval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// departureKey identifies a departure across polls, by vehicle journey and stop area
+func departureKey(c Connection) string {
+	stop := c.StopPoint.ID
+	if c.StopPoint.StopArea != nil && c.StopPoint.StopArea.ID != "" {
+		stop = c.StopPoint.StopArea.ID
+	}
+	if vj := c.VehicleJourneyID(); vj != "" {
+		return string(vj) + "|" + string(stop)
+	}
+	// Without a vehicle journey, fall back to the route and base-scheduled time
+	return string(c.Route.ID) + "@" + c.StopDateTime.BaseDepartureDateTime + "|" + string(stop)
+}
+
+// departureTime returns the time at which the vehicle leaves, or arrives if it terminates there
+func departureTime(c Connection) time.Time {
+	if !c.StopDateTime.Departure.IsZero() {
+		return c.StopDateTime.Departure
+	}
+	return c.StopDateTime.Arrival
+}
+
+// comparableNow returns now so that it can be compared with the departure time t.
+// Departure times whose time zone is unknown are wall-clock times labelled UTC,
+// to be compared with the wall clock of now rather than with its instant.
+func comparableNow(now, t time.Time) time.Time {
+	if t.Location() != time.UTC {
+		return now
+	}
+	return types.InLocation(now, time.UTC)
+}
+
+// diffDepartures compares the previous board with the departures just fetched, returning the changes and the new board.
+//
+// A departure missing from the new results is departed if its time has passed, and cancelled otherwise,
+// unless it lies after the last departure of truncated results, in which case it was merely pushed out of them.
+func diffDepartures(board map[string]Connection, current []Connection, complete bool, now time.Time) ([]DepartureEvent, map[string]Connection) {
+	var events []DepartureEvent
+	next := make(map[string]Connection, len(current))
+
+	var horizon time.Time
+	for _, c := range current {
+		key := departureKey(c)
+		next[key] = c
+		if t := departureTime(c); t.After(horizon) {
+			horizon = t
+		}
+
+		prev, ok := board[key]
+		if !ok {
+			events = append(events, DepartureEvent{Type: DepartureAdded, Connection: c})
+			continue
+		}
+		if !departureTime(prev).Equal(departureTime(c)) {
+			events = append(events, DepartureEvent{Type: DepartureDelayed, Connection: c, Previous: prev})
+		}
+		if prev.StopPoint.ID != c.StopPoint.ID || prev.StopPoint.PlatformCode != c.StopPoint.PlatformCode {
+			events = append(events, DepartureEvent{Type: DeparturePlatformChanged, Connection: c, Previous: prev})
+		}
+	}
+
+	// Now the departures which disappeared, in chronological order
+	var gone []Connection
+	for key, c := range board {
+		if _, ok := next[key]; !ok {
+			gone = append(gone, c)
+		}
+	}
+	sort.Slice(gone, func(i, j int) bool {
+		return departureTime(gone[i]).Before(departureTime(gone[j]))
+	})
+	for _, c := range gone {
+		t := departureTime(c)
+		switch {
+		case !t.After(comparableNow(now, t)):
+			events = append(events, DepartureEvent{Type: DepartureDeparted, Connection: c})
+		case complete || !t.After(horizon):
+			events = append(events, DepartureEvent{Type: DepartureCancelled, Connection: c})
+		}
+	}
+
+	return events, next
+}
@@ -0,0 +1,123 @@
+package navitia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// watchTestDeparture returns a departure of the given vehicle journey, from the given stop point of stop_area:test
+func watchTestDeparture(vj string, stopPoint string, departure string) string {
+	return fmt.Sprintf(`{
+		"links": [{"id": %q, "type": "vehicle_journey"}],
+		"stop_point": {"id": %q, "stop_area": {"id": "stop_area:test"}},
+		"route": {"id": "route:test", "is_frequence": "False"},
+		"stop_date_time": {"departure_date_time": %q, "base_departure_date_time": "20170427T100000", "data_freshness": "realtime"}
+	}`, vj, stopPoint, departure)
+}
+
+// TestScope_WatchDepartures checks the events sent while watching a departure board
+func TestScope_WatchDepartures(t *testing.T) {
+	polls := []string{
+		fmt.Sprintf(`{"context": {"current_datetime": "20170427T095500"}, "departures": [%s, %s, %s]}`,
+			watchTestDeparture("vj:A", "stop_point:1", "20170427T100000"),
+			watchTestDeparture("vj:B", "stop_point:1", "20170427T101000"),
+			watchTestDeparture("vj:C", "stop_point:1", "20170427T102000"),
+		),
+		fmt.Sprintf(`{"context": {"current_datetime": "20170427T095800"}, "departures": [%s, %s]}`,
+			watchTestDeparture("vj:A", "stop_point:1", "20170427T100300"),
+			watchTestDeparture("vj:B", "stop_point:2", "20170427T101000"),
+		),
+		fmt.Sprintf(`{"context": {"current_datetime": "20170427T100500"}, "departures": [%s]}`,
+			watchTestDeparture("vj:B", "stop_point:2", "20170427T101000"),
+		),
+	}
+
+	var mu sync.Mutex
+	poll := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		body := polls[poll]
+		if poll < len(polls)-1 {
+			poll++
+		}
+		mu.Unlock()
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	s, err := NewCustom("key", srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("error in NewCustom: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.Scope("test").WatchDepartures(ctx, "line:test", time.Millisecond); err == nil {
+		t.Error("expected an error when watching a line")
+	}
+
+	events, err := s.Scope("test").WatchDepartures(ctx, "stop_area:test", time.Millisecond)
+	if err != nil {
+		t.Fatalf("error in WatchDepartures: %v", err)
+	}
+
+	want := []struct {
+		typ DepartureEventType
+		vj  string
+	}{
+		{DepartureAdded, "vj:A"},
+		{DepartureAdded, "vj:B"},
+		{DepartureAdded, "vj:C"},
+		{DepartureDelayed, "vj:A"},
+		{DeparturePlatformChanged, "vj:B"},
+		{DepartureCancelled, "vj:C"},
+		{DepartureDeparted, "vj:A"},
+	}
+	for i, w := range want {
+		e, ok := <-events
+		if !ok {
+			t.Fatalf("event %d: channel closed early", i)
+		}
+		if e.Type != w.typ || string(e.Connection.VehicleJourneyID()) != w.vj {
+			t.Fatalf("event %d: got %s for %s, want %s for %s (error: %v)", i, e.Type, e.Connection.VehicleJourneyID(), w.typ, w.vj, e.Err)
+		}
+		if e.Type == DepartureDelayed {
+			if got := e.Connection.Delay(); got != 3*time.Minute {
+				t.Errorf("delay: got %v, want %v", got, 3*time.Minute)
+			}
+		}
+	}
+
+	// Once cancelled, the channel is closed
+	cancel()
+	for range events {
+	}
+}
+
+// Test_diffDepartures_wallClock checks that departures whose time zone is unknown are compared with the wall clock of now
+func Test_diffDepartures_wallClock(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	// 10:05 in Paris, that is 08:05 UTC
+	now := time.Date(2017, time.April, 27, 10, 5, 0, 0, paris)
+
+	board := map[string]Connection{}
+	var gone Connection
+	if err := json.Unmarshal([]byte(watchTestDeparture("vj:A", "stop_point:1", "20170427T100000")), &gone); err != nil {
+		t.Fatalf("error while unmarshalling the departure: %v", err)
+	}
+	board[departureKey(gone)] = gone
+
+	events, _ := diffDepartures(board, nil, true, now)
+	if len(events) != 1 || events[0].Type != DepartureDeparted {
+		t.Errorf("the 10:00 departure should have departed at 10:05, got %v", events)
+	}
+}